@@ -15,7 +15,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/lihuss/xiaohongshu-ai-pet-operator/internal/approval"
+	"github.com/lihuss/xiaohongshu-ai-pet-operator/internal/audit"
 	"github.com/lihuss/xiaohongshu-ai-pet-operator/internal/config"
+	"github.com/lihuss/xiaohongshu-ai-pet-operator/internal/confirm"
+	"github.com/lihuss/xiaohongshu-ai-pet-operator/internal/quota"
+	"github.com/lihuss/xiaohongshu-ai-pet-operator/internal/replies"
 	"github.com/lihuss/xiaohongshu-ai-pet-operator/internal/xhs"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/modelcontextprotocol/go-sdk/server"
@@ -31,6 +36,14 @@ type petSession struct {
 	StopReason     string
 }
 
+// publishTimeout 发布类动作等待引擎响应的最长时间，需覆盖下载并上传图片、
+// 等待发布成功（最长 60 秒）以及在笔记管理页查找新笔记
+const publishTimeout = 10 * time.Minute
+
+// replyCheckTimeout 回复检查每次打开一条评论的楼中楼并展开回复，
+// 评论多时 comment_thread 要几分钟，普通的 30 秒超时会在引擎读完前断开
+const replyCheckTimeout = 5 * time.Minute
+
 var (
 	sessionMu sync.Mutex
 	session   *petSession
@@ -87,7 +100,14 @@ func main() {
 		goCmd = "go.exe"
 	}
 	
-	cmd := exec.Command(goCmd, "run", ".", "-port", mcpPort, "-headless=false")
+	engineArgs := []string{"run", ".", "-port", mcpPort, "-headless=false"}
+	if cfg.Messages.Enabled {
+		engineArgs = append(engineArgs, "-enable-messages")
+		if cfg.Messages.OwnerOnly {
+			engineArgs = append(engineArgs, "-message-allow="+cfg.OwnerUserID)
+		}
+	}
+	cmd := exec.Command(goCmd, engineArgs...)
 	cmd.Dir = engineDir
 	cmd.Stdout = os.Stderr // 引擎日志重定向到 stderr，不影响 MCP Stdio
 	cmd.Stderr = os.Stderr
@@ -105,6 +125,9 @@ func main() {
 
 	// 5. 等待引擎启动就绪 (通过轮询 /health)
 	xhsClient := xhs.NewClient(mcpBaseURL, 30*time.Second)
+	// 发布要上传图片并等待发布成功、查找新笔记，耗时远超普通动作；
+	// 用普通超时会在引擎发布到一半时断开，笔记可能已发出却被记为失败
+	publishClient := xhs.NewClient(mcpBaseURL, publishTimeout)
 	ready := false
 	for i := 0; i < 20; i++ {
 		// 这里简单检查，Execute 一个不带签名的 health 动作或者直接 HTTP GET
@@ -118,6 +141,51 @@ func main() {
 		log.Fatalf("Engine failed to be ready at %s", mcpBaseURL)
 	}
 
+	// 后台定期检查别人对宠物评论的回复
+	replyTracker, err := replies.NewTracker(cfg.Replies.StorePath, xhs.NewClient(mcpBaseURL, replyCheckTimeout))
+	if err != nil {
+		log.Fatalf("Load replies tracker failed: %v", err)
+	}
+	trackerCtx, stopTracker := context.WithCancel(context.Background())
+	defer stopTracker()
+	ownerAudit := audit.NewLogger(cfg.AuditPath)
+
+	// 关注等敏感动作的频率限制
+	limits := make(map[string]quota.Limit, len(cfg.Quota.Limits))
+	for command, l := range cfg.Quota.Limits {
+		limits[command] = quota.Limit{PerHour: l.PerHour, PerDay: l.PerDay}
+	}
+	limiter, err := quota.NewLimiter(cfg.Quota.Path, limits)
+	if err != nil {
+		log.Fatalf("Load quota state failed: %v", err)
+	}
+	// 删除笔记等破坏性动作需要主人给出确认码
+	confirmations := confirm.NewStore(cfg.Confirm.TTL)
+	// 宠物发布的笔记先进入审核队列，主人通过后才发给引擎
+	publishQueue, err := approval.NewQueue(cfg.Approval.Path, filepath.Join(cfg.Approval.OwnerDir, "approval.key"))
+	if err != nil {
+		log.Fatalf("Load publish queue failed: %v", err)
+	}
+	reviewBaseURL := "http://" + cfg.Approval.Listen
+	go func() {
+		handler := approval.Handler(publishQueue, confirmations, func(it approval.Item) {
+			publishApproved(publishClient, publishQueue, ownerAudit, limiter, it)
+		})
+		if err := http.ListenAndServe(cfg.Approval.Listen, handler); err != nil {
+			log.Printf("publish review page stopped: %v", err)
+		}
+	}()
+	// 审核页链接带有令牌，只写入主人目录，不打印到宠物可能看到的日志中
+	reviewLinkPath := filepath.Join(cfg.Approval.OwnerDir, "review_link.txt")
+	if err := os.WriteFile(reviewLinkPath, []byte(approval.PageURL(reviewBaseURL, publishQueue)+"\n"), 0o600); err != nil {
+		log.Fatalf("Save publish review link failed: %v", err)
+	}
+	log.Printf("publish review page listening on %s", reviewBaseURL)
+	go replyTracker.Run(trackerCtx, cfg.Replies.CheckInterval, func() bool {
+		ok, _, err := checkLogin(mcpBaseURL)
+		return err == nil && ok
+	})
+
 	// 6. 初始化 MCP Server
 	s := server.NewServer(
 		&mcp.Implementation{
@@ -197,22 +265,342 @@ func main() {
 				Required: []string{"keyword"},
 			},
 		},
+		{
+			Name:        "search_suggest",
+			Description: "获取搜索框对关键词前缀的联想词，帮你挑选更具体、更有人气的搜索词",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"keyword": map[string]interface{}{"type": "string", "description": "关键词前缀"},
+				},
+				Required: []string{"keyword"},
+			},
+		},
+		{
+			Name:        "trending_keywords",
+			Description: "获取小红书当前的热搜榜关键词及热度",
+		},
+		{
+			Name:        "topic_feeds",
+			Description: "打开小红书话题页，获取话题浏览量、笔记数及话题下的笔记列表",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"topic_id": map[string]interface{}{"type": "string", "description": "话题ID，从笔记详情的topics字段获取；id为空的话题只出现在正文里，不能用于本工具"},
+					"limit":    map[string]interface{}{"type": "integer", "description": "返回的笔记数量（可选），默认20"},
+				},
+				Required: []string{"topic_id"},
+			},
+		},
 		{
 			Name:        "publish_content",
-			Description: "通过你的小红书宠物发布图文笔记",
+			Description: "通过你的小红书宠物发布图文笔记。笔记会先交给主人审核，主人通过后才会发出；用 publish_queue 查看审核结果",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
-					"title":   map[string]interface{}{"type": "string", "description": "笔记标题"},
-					"content": map[string]interface{}{"type": "string", "description": "笔记正文内容"},
-					"images":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "本地图片绝对路径或有效URL列表"},
+					"title":    map[string]interface{}{"type": "string", "description": "笔记标题"},
+					"content":  map[string]interface{}{"type": "string", "description": "笔记正文内容"},
+					"images":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "本地图片绝对路径或有效URL列表"},
+					"tags":     map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "话题标签（可选），不带#"},
+					"mentions": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "要@的用户ID或昵称（可选），会插入真正的@提及"},
+					"draft":    map[string]interface{}{"type": "boolean", "description": "只存入草稿箱不发布（可选），留给主人审核"},
 				},
 				Required: []string{"title", "content", "images"},
 			},
 		},
 		{
 			Name:        "list_feeds",
-			Description: "获取小红书首页推荐的内容流",
+			Description: "获取小红书首页推荐的内容流（可附带封面缩略图，让你看到封面画面）",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"attach_images": map[string]interface{}{"type": "boolean", "description": "是否附带封面缩略图"},
+				},
+			},
+		},
+		{
+			Name:        "feed_detail",
+			Description: "获取笔记详情与评论（可附带笔记图片缩略图，让你看到图片内容再互动）",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feed_id":           map[string]interface{}{"type": "string", "description": "笔记ID，从Feed列表获取"},
+					"xsec_token":        map[string]interface{}{"type": "string", "description": "访问令牌，从Feed列表的xsecToken字段获取"},
+					"load_all_comments": map[string]interface{}{"type": "boolean", "description": "是否加载更多评论，默认只返回前10条"},
+					"attach_images":     map[string]interface{}{"type": "boolean", "description": "是否附带笔记图片缩略图"},
+					"comment_digest":    map[string]interface{}{"type": "boolean", "description": "是否附带评论区摘要（高赞评论、关键词、提问、属地分布）"},
+					"digest_only":       map[string]interface{}{"type": "boolean", "description": "只要评论区摘要、不要评论原文，评论很多时用它节省上下文"},
+				},
+				Required: []string{"feed_id", "xsec_token"},
+			},
+		},
+		{
+			Name:        "list_comments",
+			Description: "分页读取笔记的一级评论，一次读一小批；首次不传cursor，之后传上一页返回的cursor",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feed_id":    map[string]interface{}{"type": "string", "description": "笔记ID，从Feed列表获取"},
+					"xsec_token": map[string]interface{}{"type": "string", "description": "访问令牌，从Feed列表的xsecToken字段获取"},
+					"cursor":     map[string]interface{}{"type": "string", "description": "上一页返回的cursor，为空读取第一页"},
+					"limit":      map[string]interface{}{"type": "integer", "description": "每页评论数，默认10，最多50"},
+				},
+				Required: []string{"feed_id", "xsec_token"},
+			},
+		},
+		{
+			Name:        "post_comment",
+			Description: "在笔记下发表评论，成功后返回新评论的comment_id，可用于之后查看或回复这条评论下的回复",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feed_id":    map[string]interface{}{"type": "string", "description": "笔记ID"},
+					"xsec_token": map[string]interface{}{"type": "string", "description": "访问令牌"},
+					"content":    map[string]interface{}{"type": "string", "description": "评论内容，可包含 [笑哭R] 形式的表情"},
+					"mentions":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "要@的用户ID或昵称（可选），不要把@写进content"},
+					"image":      map[string]interface{}{"type": "string", "description": "附带一张图片（可选），本地图片绝对路径或图片URL"},
+				},
+				Required: []string{"feed_id", "xsec_token", "content"},
+			},
+		},
+		{
+			Name:        "reply_comment",
+			Description: "回复笔记下的某条评论，成功后返回新回复的comment_id",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feed_id":    map[string]interface{}{"type": "string", "description": "笔记ID"},
+					"xsec_token": map[string]interface{}{"type": "string", "description": "访问令牌"},
+					"comment_id": map[string]interface{}{"type": "string", "description": "要回复的评论ID"},
+					"user_id":    map[string]interface{}{"type": "string", "description": "要回复的评论作者ID（没有comment_id时使用）"},
+					"content":    map[string]interface{}{"type": "string", "description": "回复内容，可包含 [笑哭R] 形式的表情"},
+					"mentions":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "要@的用户ID或昵称（可选），不要把@写进content"},
+					"image":      map[string]interface{}{"type": "string", "description": "附带一张图片（可选），本地图片绝对路径或图片URL"},
+				},
+				Required: []string{"feed_id", "xsec_token", "content"},
+			},
+		},
+		{
+			Name:        "delete_comment",
+			Description: "删除你（宠物）自己发过的评论或回复，用于撤回说错的话；不能删别人的评论，每次删除都会记录给主人查看",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feed_id":    map[string]interface{}{"type": "string", "description": "笔记ID"},
+					"xsec_token": map[string]interface{}{"type": "string", "description": "访问令牌"},
+					"comment_id": map[string]interface{}{"type": "string", "description": "要删除的评论ID（post_comment/reply_comment 返回的 comment_id）"},
+				},
+				Required: []string{"feed_id", "xsec_token", "comment_id"},
+			},
+		},
+		{
+			Name:        "list_emojis",
+			Description: "列出评论里能用的小红书表情名称（如 [笑哭R]），在评论或回复内容里直接写这些名称就会变成平台表情",
+		},
+		{
+			Name:        "like_comment",
+			Description: "给笔记下的某条评论或回复点赞，或取消点赞；已经是目标状态时不会重复点击",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feed_id":    map[string]interface{}{"type": "string", "description": "笔记ID"},
+					"xsec_token": map[string]interface{}{"type": "string", "description": "访问令牌"},
+					"comment_id": map[string]interface{}{"type": "string", "description": "要点赞的评论或回复ID"},
+					"unlike":     map[string]interface{}{"type": "boolean", "description": "为 true 时取消点赞"},
+				},
+				Required: []string{"feed_id", "xsec_token", "comment_id"},
+			},
+		},
+		{
+			Name:        "follow_user",
+			Description: "关注或取消关注一个用户：传 user_id 在对方主页操作，或传 feed_id 关注这篇笔记的作者；已经是目标状态时不会重复点击。关注有频率限制，超出时会提示多久后再试",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"user_id":    map[string]interface{}{"type": "string", "description": "要关注的用户ID，与 feed_id 二选一"},
+					"feed_id":    map[string]interface{}{"type": "string", "description": "笔记ID，关注这篇笔记的作者"},
+					"xsec_token": map[string]interface{}{"type": "string", "description": "访问令牌，与 user_id 或 feed_id 对应"},
+					"unfollow":   map[string]interface{}{"type": "boolean", "description": "为 true 时取消关注"},
+				},
+				Required: []string{"xsec_token"},
+			},
+		},
+		{
+			Name:        "list_followers",
+			Description: "一页一页查看你（宠物）的粉丝，最新关注的在前；mutual 为 false 的是还没回关的新粉丝",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"cursor": map[string]interface{}{"type": "string", "description": "上一页返回的 cursor，第一页不传"},
+					"limit":  map[string]interface{}{"type": "integer", "description": "每页人数，默认20，最多100"},
+				},
+			},
+		},
+		{
+			Name:        "list_followings",
+			Description: "一页一页查看你（宠物）关注了谁，以及是否互相关注",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"cursor": map[string]interface{}{"type": "string", "description": "上一页返回的 cursor，第一页不传"},
+					"limit":  map[string]interface{}{"type": "integer", "description": "每页人数，默认20，最多100"},
+				},
+			},
+		},
+		{
+			Name:        "get_notifications",
+			Description: "看看别人对你（宠物）做了什么：评论和@、赞和收藏、新增关注。传 since（如 24h 或上次查看的时间）只看新的通知",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"tabs":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string", "enum": []string{"comments", "likes", "follows"}}, "description": "只看哪些标签（可选）：comments=评论和@，likes=赞和收藏，follows=新增关注；不传表示全部"},
+					"since": map[string]interface{}{"type": "string", "description": "只看这个时间之后的通知（可选），如 24h、2025-03-01 或 RFC3339 时间"},
+					"limit": map[string]interface{}{"type": "integer", "description": "每个标签最多条数，默认30，最多100"},
+				},
+			},
+		},
+		{
+			Name:        "list_conversations",
+			Description: "查看私信会话列表（最近的在前），包含对方昵称、用户ID、最后一条消息和未读数。私信需主人在配置中开启，默认只能和主人私信",
+		},
+		{
+			Name:        "read_conversation",
+			Description: "读取和某个人的私信，返回最近的消息，fromMe 表示是不是你发的",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"user_id": map[string]interface{}{"type": "string", "description": "会话对方的用户ID"},
+					"limit":   map[string]interface{}{"type": "integer", "description": "最近消息条数，默认20，最多100"},
+				},
+				Required: []string{"user_id"},
+			},
+		},
+		{
+			Name:        "send_message",
+			Description: "在已有私信会话里给对方发一条文字私信，每条都会记录给主人查看",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"user_id": map[string]interface{}{"type": "string", "description": "接收私信的用户ID"},
+					"content": map[string]interface{}{"type": "string", "description": "私信内容，最多500字"},
+				},
+				Required: []string{"user_id", "content"},
+			},
+		},
+		{
+			Name:        "list_my_notes",
+			Description: "查看你（宠物）自己最近发布的笔记和数据：观看、点赞、评论、收藏、分享，以及是否仅自己可见",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"limit": map[string]interface{}{"type": "integer", "description": "最多返回的笔记数，默认20，最多100"},
+				},
+			},
+		},
+		{
+			Name:        "creator_stats",
+			Description: "采集你（宠物）账号在创作中心的数据：曝光、观看、封面点击率、完播率、涨粉，以及最近笔记的数据。每次采集都会存一份快照，用来之后看趋势。较慢，一天采集一两次就够了",
+		},
+		{
+			Name:        "creator_stats_trend",
+			Description: "对比本地保存的数据快照，看从某个时间到最近一次采集，总粉丝数和每篇笔记的累计数据涨了多少；近7日这类滚动数据只给最新值，不能相减当增长",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"since": map[string]interface{}{"type": "string", "description": "从什么时候开始比较（可选），如 168h、2025-03-01；不传表示从最早的快照开始"},
+				},
+			},
+		},
+		{
+			Name:        "delete_note",
+			Description: "删除你自己发布的一篇笔记，无法恢复。需要主人确认：第一次调用会生成确认码交给主人，主人把确认码告诉你后带上 confirm_code 再调用",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"note_id":      map[string]interface{}{"type": "string", "description": "要删除的笔记ID，从 list_my_notes 获取"},
+					"confirm_code": map[string]interface{}{"type": "string", "description": "主人给你的确认码（第一次调用不传）"},
+				},
+				Required: []string{"note_id"},
+			},
+		},
+		{
+			Name:        "set_note_visibility",
+			Description: "把你自己发布的笔记设为仅自己可见或重新公开。需要主人确认，流程同 delete_note",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"note_id":      map[string]interface{}{"type": "string", "description": "笔记ID，从 list_my_notes 获取"},
+					"private":      map[string]interface{}{"type": "boolean", "description": "true=仅自己可见，false=公开可见"},
+					"confirm_code": map[string]interface{}{"type": "string", "description": "主人给你的确认码（第一次调用不传）"},
+				},
+				Required: []string{"note_id", "private"},
+			},
+		},
+		{
+			Name:        "publish_queue",
+			Description: "查看你提交给主人审核的笔记：待审核、已拒绝（附主人给的原因）、已发布或发布失败",
+		},
+		{
+			Name:        "list_drafts",
+			Description: "查看创作中心草稿箱里的草稿（最近保存的在前），包含位置 index、标题和正文摘要",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"kind": map[string]interface{}{"type": "string", "enum": []string{"image", "video"}, "description": "草稿类型，默认 image（图文）"},
+				},
+			},
+		},
+		{
+			Name:        "publish_draft",
+			Description: "发布草稿箱里的一篇草稿。需要主人确认，流程同 delete_note",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"kind":         map[string]interface{}{"type": "string", "enum": []string{"image", "video"}, "description": "草稿类型，默认 image（图文）"},
+					"index":        map[string]interface{}{"type": "integer", "description": "草稿位置，从 list_drafts 获取"},
+					"title":        map[string]interface{}{"type": "string", "description": "草稿标题，从 list_drafts 获取，用来核对草稿"},
+					"confirm_code": map[string]interface{}{"type": "string", "description": "主人给你的确认码（第一次调用不传）"},
+				},
+				Required: []string{"index", "title"},
+			},
+		},
+		{
+			Name:        "delete_draft",
+			Description: "删除草稿箱里的一篇草稿，无法恢复。需要主人确认，流程同 delete_note",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"kind":         map[string]interface{}{"type": "string", "enum": []string{"image", "video"}, "description": "草稿类型，默认 image（图文）"},
+					"index":        map[string]interface{}{"type": "integer", "description": "草稿位置，从 list_drafts 获取"},
+					"title":        map[string]interface{}{"type": "string", "description": "草稿标题，从 list_drafts 获取，用来核对草稿"},
+					"confirm_code": map[string]interface{}{"type": "string", "description": "主人给你的确认码（第一次调用不传）"},
+				},
+				Required: []string{"index", "title"},
+			},
+		},
+		{
+			Name:        "unanswered_replies",
+			Description: "列出别人回复你（宠物）评论、而你还没回应的回复，附带你原来的评论和楼层上下文",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"refresh": map[string]interface{}{"type": "boolean", "description": "是否先立即检查一轮新回复（会打开几个笔记页面，较慢）"},
+				},
+			},
+		},
+		{
+			Name:        "comment_thread",
+			Description: "只展开某条评论的回复，返回按回复关系嵌套的回复树，用来看清楼中楼的对话",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feed_id":    map[string]interface{}{"type": "string", "description": "笔记ID"},
+					"xsec_token": map[string]interface{}{"type": "string", "description": "访问令牌"},
+					"comment_id": map[string]interface{}{"type": "string", "description": "一级评论ID或其下某条回复的ID"},
+					"limit":      map[string]interface{}{"type": "integer", "description": "最多返回的回复数，默认20"},
+				},
+				Required: []string{"feed_id", "xsec_token", "comment_id"},
+			},
 		},
 	}
 
@@ -323,13 +711,115 @@ func main() {
 				}
 			}
 
-			data, _, err := xhsClient.Execute(ctx, tool.Name, args)
+			if isMessageCommand(tool.Name) && !cfg.Messages.Enabled {
+				return mcp.NewToolResultError("私信功能未开启。需要主人在 config/user.config.json 中设置 messages.enabled。"), nil
+			}
+
+			if needsOwnerConfirm(tool.Name) {
+				target := confirmTarget(tool.Name, args)
+				code := strFromArgs(args, "confirm_code", "")
+				delete(args, "confirm_code")
+				if !confirmations.Redeem(tool.Name, target, code) {
+					if _, err := confirmations.Issue(tool.Name, target); err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("生成主人确认码失败: %v", err)), nil
+					}
+					log.Printf("owner confirm code issued for %s %s, shown on the review page", tool.Name, target)
+					msg := "这个动作需要主人确认。"
+					if code != "" {
+						msg = "确认码不对或已过期，已重新生成。"
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("%s新的确认码只有主人能在审核页面上看到，%d 分钟内有效。请告诉主人你要做什么，等主人把确认码告诉你后，带上 confirm_code 用同样的参数重新调用。", msg, int(cfg.Confirm.TTL.Minutes()))), nil
+				}
+			}
+
+			if tool.Name == "publish_queue" {
+				items := publishQueue.List()
+				b, _ := json.MarshalIndent(map[string]any{"count": len(items), "items": items}, "", "  ")
+				return mcp.NewToolResultText(string(b)), nil
+			}
+
+			if tool.Name == "unanswered_replies" {
+				if refresh, _ := args["refresh"].(bool); refresh {
+					if _, err := replyTracker.Check(ctx); err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("检查新回复失败: %v", err)), nil
+					}
+				}
+				pending := replyTracker.Unanswered()
+				if len(pending) == 0 {
+					return mcp.NewToolResultText("暂时没有待回应的回复。"), nil
+				}
+				b, _ := json.MarshalIndent(map[string]any{"count": len(pending), "replies": pending}, "", "  ")
+				return mcp.NewToolResultText(string(b)), nil
+			}
+
+			attachImages, _ := args["attach_images"].(bool)
+			if attachImages {
+				args["max_images"] = cfg.Vision.MaxImages
+				args["max_image_dimension"] = cfg.Vision.MaxImageDimension
+			}
+
+			slot, err := limiter.Allow(tool.Name)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s 暂时不能执行: %v", tool.Name, err)), nil
+			}
+
+			if tool.Name == "publish_content" {
+				if draft, _ := args["draft"].(bool); !draft {
+					// 提交审核不算一次发布，主人通过后发布成功才计数
+					releaseQuota(limiter, slot)
+					it, err := publishQueue.Enqueue(tool.Name, args)
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("提交主人审核失败: %v", err)), nil
+					}
+					log.Printf("publication %s %q waiting for owner review", it.ID, it.Title())
+					return mcp.NewToolResultText(fmt.Sprintf("笔记《%s》已提交给主人审核（编号 %s），主人通过后会自动发布。请告诉主人去审核页面查看，之后用 publish_queue 查看结果，不要重复提交。", it.Title(), it.ID)), nil
+				}
+			}
+
+			client := xhsClient
+			if isPublishCommand(tool.Name) {
+				client = publishClient
+			}
+			data, status, err := client.Execute(ctx, tool.Name, args)
+			if tool.Name == "delete_comment" {
+				auditDeleteComment(ownerAudit, replyTracker, args, data, err)
+			}
+			if tool.Name == "send_message" || needsOwnerConfirm(tool.Name) {
+				recordAudit(ownerAudit, auditEntry(tool.Name, args, data, err))
+			}
 			if err != nil {
+				// 请求已发出后断开或超时（StatusBadGateway）时，引擎可能已经执行成功，
+				// 保留占用的次数，宁可少做一次也不超出上限；只有明确失败的响应才归还
+				if status != http.StatusBadGateway {
+					releaseQuota(limiter, slot)
+				}
 				return mcp.NewToolResultError(fmt.Sprintf("AI宠物的动作执行失败: %v", err)), nil
 			}
 
+			if tool.Name == "post_comment" || tool.Name == "reply_comment" {
+				trackPostedComment(replyTracker, tool.Name, args, data)
+			}
+			if !countsForQuota(data) {
+				releaseQuota(limiter, slot)
+			}
+
+			images := takeImages(data)
 			b, _ := json.MarshalIndent(data, "", "  ")
-			return mcp.NewToolResultText(string(b)), nil
+			if len(images) == 0 {
+				return mcp.NewToolResultText(string(b)), nil
+			}
+
+			contents := []mcp.Content{mcp.NewTextContent(string(b))}
+			for i, img := range images {
+				feedID, _ := img["feed_id"].(string)
+				imgData, _ := img["data"].(string)
+				mimeType, _ := img["mime_type"].(string)
+				contents = append(contents,
+					mcp.NewTextContent(fmt.Sprintf("[图片 %d] feed_id=%s", i+1, feedID)),
+					mcp.NewImageContent(imgData, mimeType),
+				)
+			}
+			return &mcp.CallToolResult{Content: contents}, nil
 		})
 	}
 
@@ -370,6 +860,170 @@ func strFromArgs(args map[string]any, key string, fallback string) string {
 	return s
 }
 
+// trackPostedComment records a successful comment or reply so replies to it are
+// tracked, and marks the reply it answered as handled.
+func trackPostedComment(tracker *replies.Tracker, command string, args, data map[string]any) {
+	if ok, _ := data["success"].(bool); !ok {
+		return
+	}
+	inner, _ := data["data"].(map[string]any)
+	commentID, _ := inner["comment_id"].(string)
+
+	err := tracker.RecordPosted(replies.PostedComment{
+		CommentID: commentID,
+		FeedID:    strFromArgs(args, "feed_id", ""),
+		XsecToken: strFromArgs(args, "xsec_token", ""),
+		Content:   strFromArgs(args, "content", ""),
+	})
+	if err != nil {
+		log.Printf("record posted comment failed: %v", err)
+	}
+
+	if command == "reply_comment" {
+		if target := strFromArgs(args, "comment_id", ""); target != "" {
+			if err := tracker.MarkAnswered(target); err != nil {
+				log.Printf("mark reply answered failed: %v", err)
+			}
+		}
+	}
+}
+
+// countsForQuota reports whether a command's result should count against its
+// limit: it must have succeeded and actually changed something; a follow that
+// was already in place costs nothing.
+func countsForQuota(data map[string]any) bool {
+	if ok, _ := data["success"].(bool); !ok {
+		return false
+	}
+	if inner, ok := data["data"].(map[string]any); ok {
+		if changed, ok := inner["changed"].(bool); ok && !changed {
+			return false
+		}
+	}
+	return true
+}
+
+// releaseQuota gives back a slot reserved by Allow for a run that did not count.
+func releaseQuota(limiter *quota.Limiter, slot quota.Reservation) {
+	if err := limiter.Release(slot); err != nil {
+		log.Printf("release quota failed: %v", err)
+	}
+}
+
+// publishApproved sends a publication the owner approved to the engine and
+// records the outcome in the queue and the owner audit.
+func publishApproved(client *xhs.Client, queue *approval.Queue, logger *audit.Logger, limiter *quota.Limiter, it approval.Item) {
+	data, _, err := client.Execute(context.Background(), it.Command, it.Args)
+	recordAudit(logger, auditEntry(it.Command, it.Args, data, err))
+	if err == nil && countsForQuota(data) {
+		if err := limiter.Record(it.Command); err != nil {
+			log.Printf("record quota failed: %v", err)
+		}
+	}
+	if err := queue.Finish(it.ID, data, err); err != nil {
+		log.Printf("record publication %s failed: %v", it.ID, err)
+	}
+}
+
+// auditDeleteComment writes an owner audit entry for every delete attempt and
+// stops tracking replies to a comment once it is gone.
+func auditDeleteComment(logger *audit.Logger, tracker *replies.Tracker, args, data map[string]any, execErr error) {
+	entry := auditEntry("delete_comment", args, data, execErr)
+	recordAudit(logger, entry)
+
+	if entry.OK {
+		if err := tracker.Forget(strFromArgs(args, "comment_id", "")); err != nil {
+			log.Printf("forget deleted comment failed: %v", err)
+		}
+	}
+}
+
+// auditEntry builds an owner audit entry from the outcome of an engine command.
+func auditEntry(command string, args, data map[string]any, execErr error) audit.Entry {
+	entry := audit.Entry{Command: command, Args: args}
+	switch {
+	case execErr != nil:
+		entry.Detail = execErr.Error()
+	default:
+		entry.OK, _ = data["success"].(bool)
+		if !entry.OK {
+			entry.Detail = fmt.Sprintf("%v", data["details"])
+		}
+	}
+	return entry
+}
+
+func recordAudit(logger *audit.Logger, entry audit.Entry) {
+	if err := logger.Record(entry); err != nil {
+		log.Printf("write owner audit failed: %v", err)
+	}
+}
+
+// isPublishCommand reports whether command publishes a note, which can keep
+// the engine busy far longer than other commands.
+func isPublishCommand(command string) bool {
+	switch command {
+	case "publish_content", "publish_video", "publish_draft":
+		return true
+	}
+	return false
+}
+
+// isMessageCommand reports whether command reads or sends direct messages.
+func isMessageCommand(command string) bool {
+	switch command {
+	case "list_conversations", "read_conversation", "send_message":
+		return true
+	}
+	return false
+}
+
+// needsOwnerConfirm reports whether command must be approved by the owner with
+// a one-time confirmation code before it runs.
+func needsOwnerConfirm(command string) bool {
+	switch command {
+	case "delete_note", "set_note_visibility", "publish_draft", "delete_draft":
+		return true
+	}
+	return false
+}
+
+// confirmTarget describes exactly what a confirmation code approves, so a code
+// for hiding a note cannot be reused to delete it or to act on another note.
+func confirmTarget(command string, args map[string]any) string {
+	if command == "publish_draft" || command == "delete_draft" {
+		return fmt.Sprintf("%s draft #%v %q", strFromArgs(args, "kind", "image"), args["index"], strFromArgs(args, "title", ""))
+	}
+	target := strFromArgs(args, "note_id", "")
+	if command == "set_note_visibility" {
+		private, _ := args["private"].(bool)
+		target = fmt.Sprintf("%s private=%v", target, private)
+	}
+	return target
+}
+
+// takeImages removes the base64 thumbnails from an engine response so they can be
+// returned as image content instead of bloating the JSON text.
+func takeImages(data map[string]any) []map[string]any {
+	inner, ok := data["data"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	raw, ok := inner["images"].([]any)
+	if !ok {
+		return nil
+	}
+	delete(inner, "images")
+
+	images := make([]map[string]any, 0, len(raw))
+	for _, item := range raw {
+		if img, ok := item.(map[string]any); ok {
+			images = append(images, img)
+		}
+	}
+	return images
+}
+
 func checkLogin(baseURL string) (bool, string, error) {
 	cli := &http.Client{Timeout: 10 * time.Second}
 	resp, err := cli.Get(baseURL + "/api/v1/login/status")
@@ -0,0 +1,156 @@
+package approval
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/lihuss/xiaohongshu-ai-pet-operator/internal/confirm"
+)
+
+// Handler serves the owner's review page and the signed decision endpoint.
+//
+//	GET  /?token=...                                   review page
+//	POST /decide?id=...&decision=approve|reject&sig=...  apply a decision
+//
+// The page also lists the pending confirmation codes from codes, so they
+// reach the owner without passing through files or logs the pet can read.
+// onApprove is called in its own goroutine for every approved item and must
+// report the outcome with Queue.Finish.
+func Handler(q *Queue, codes *confirm.Store, onApprove func(Item)) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		token := r.URL.Query().Get("token")
+		if !hmac.Equal([]byte(token), []byte(q.PageToken())) {
+			http.Error(w, "invalid token", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := pageTmpl.Execute(w, pageData{Token: token, Codes: codes.List(), Items: q.List(), q: q}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/decide", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		it, err := q.Decide(r.FormValue("id"), r.FormValue("decision"), r.FormValue("sig"), r.FormValue("reason"))
+		switch {
+		case errors.Is(err, ErrBadSig):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if it.Status == StatusPublishing {
+			go onApprove(it)
+		}
+
+		if token := r.FormValue("token"); token != "" {
+			http.Redirect(w, r, "/?token="+url.QueryEscape(token), http.StatusSeeOther)
+			return
+		}
+		fmt.Fprintf(w, "%s %s\n", it.ID, it.Status)
+	})
+
+	return mux
+}
+
+// PageURL is the review page link including its token. It must only reach
+// the owner: the plugin writes it to an owner-only file and never logs it.
+func PageURL(base string, q *Queue) string {
+	return base + "/?token=" + url.QueryEscape(q.PageToken())
+}
+
+type pageData struct {
+	Token string
+	Codes []confirm.Pending
+	Items []Item
+	q     *Queue
+}
+
+func (d pageData) Sig(it Item, decision string) string {
+	return d.q.Sign(it, decision)
+}
+
+var statusText = map[string]string{
+	StatusPending:    "待审核",
+	StatusRejected:   "已拒绝",
+	StatusPublishing: "发布中",
+	StatusPublished:  "已发布",
+	StatusFailed:     "发布失败",
+}
+
+var pageTmpl = template.Must(template.New("page").Funcs(template.FuncMap{
+	"status": func(s string) string { return statusText[s] },
+}).Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<title>宠物发布审核</title>
+<style>
+body { font-family: sans-serif; max-width: 760px; margin: 2em auto; color: #222; }
+.item { border: 1px solid #ddd; border-radius: 8px; padding: 1em; margin-bottom: 1em; }
+.pending { border-color: #ff2442; }
+.content { white-space: pre-wrap; }
+.meta { color: #888; font-size: 0.9em; }
+form { display: inline; }
+</style>
+</head>
+<body>
+<h1>宠物发布审核</h1>
+{{with .Codes}}
+<h2>待确认的动作</h2>
+<p class="meta">宠物要做下面的事，同意的话把确认码告诉宠物；不同意就不用理会，确认码过期后自动作废。</p>
+{{range .}}
+<div class="item pending">
+  <p><b>{{.Code}}</b> · {{.Command}} {{.Target}}</p>
+  <p class="meta">{{.ExpiresAt.Format "15:04"}} 前有效</p>
+</div>
+{{end}}
+{{end}}
+{{if not .Items}}<p>还没有待审核的发布。</p>{{end}}
+{{range .Items}}
+<div class="item {{.Status}}">
+  <p class="meta">#{{.ID}} · {{.CreatedAt.Format "2006-01-02 15:04"}} · {{status .Status}}{{with .Reason}} · {{.}}{{end}}</p>
+  <h2>{{.Title}}</h2>
+  <p class="content">{{.Content}}</p>
+  {{with .Tags}}<p>标签：{{range .}}#{{.}} {{end}}</p>{{end}}
+  {{with .Mentions}}<p>提及：{{range .}}@{{.}} {{end}}</p>{{end}}
+  {{with .Images}}<p>图片：</p><ul>{{range .}}<li>{{.}}</li>{{end}}</ul>{{end}}
+  {{if eq .Status "pending"}}
+  <form method="post" action="/decide">
+    <input type="hidden" name="id" value="{{.ID}}">
+    <input type="hidden" name="decision" value="approve">
+    <input type="hidden" name="sig" value="{{$.Sig . "approve"}}">
+    <input type="hidden" name="token" value="{{$.Token}}">
+    <button type="submit">通过并发布</button>
+  </form>
+  <form method="post" action="/decide">
+    <input type="hidden" name="id" value="{{.ID}}">
+    <input type="hidden" name="decision" value="reject">
+    <input type="hidden" name="sig" value="{{$.Sig . "reject"}}">
+    <input type="hidden" name="token" value="{{$.Token}}">
+    <input type="text" name="reason" placeholder="拒绝原因（可选，会告诉宠物）">
+    <button type="submit">拒绝</button>
+  </form>
+  {{end}}
+</div>
+{{end}}
+</body>
+</html>
+`))
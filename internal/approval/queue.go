@@ -0,0 +1,363 @@
+// Package approval holds the pet's publications until the owner reviews them.
+// The pet can only enqueue; approving or rejecting needs a signature made with
+// a secret that is never returned through MCP tools, never logged, and kept in
+// a key file outside the project tree rather than next to the queue. This
+// stops the pet from approving its own posts through the project files; a pet
+// given unrestricted shell access as the owner's OS user can still read the
+// key, so that setup is not covered.
+package approval
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status of a queued publication.
+const (
+	StatusPending    = "pending"
+	StatusRejected   = "rejected"
+	StatusPublishing = "publishing"
+	StatusPublished  = "published"
+	StatusFailed     = "failed"
+)
+
+// Decisions the owner can sign.
+const (
+	DecisionApprove = "approve"
+	DecisionReject  = "reject"
+)
+
+var (
+	ErrNotFound    = errors.New("publication not found")
+	ErrDecided     = errors.New("publication already decided")
+	ErrBadSig      = errors.New("invalid signature")
+	ErrBadDecision = errors.New("unknown decision")
+	ErrBadArgs     = errors.New("invalid publish arguments")
+)
+
+// publishTextKeys and publishListKeys are the publish_content arguments kept
+// at enqueue time. Any other key the pet sends, such as schedule_at, is
+// dropped, so everything the engine receives is shown on the review page.
+var (
+	publishTextKeys = []string{"title", "content"}
+	publishListKeys = []string{"images", "tags", "mentions"}
+)
+
+// Item is one publication waiting for, or past, the owner's review.
+type Item struct {
+	ID        string         `json:"id"`
+	Command   string         `json:"command"`
+	Args      map[string]any `json:"args"` // sent to the engine unchanged once approved; the preview is rendered from it
+	Status    string         `json:"status"`
+	Reason    string         `json:"reason,omitempty"` // owner's rejection reason or the publish error
+	Result    map[string]any `json:"result,omitempty"` // engine response after publishing
+	CreatedAt time.Time      `json:"created_at"`
+	DecidedAt *time.Time     `json:"decided_at,omitempty"`
+}
+
+// Queue keeps publications in a JSON file so pending reviews survive restarts.
+type Queue struct {
+	path string
+
+	mu     sync.Mutex
+	secret []byte
+	items  []*Item
+}
+
+type queueFile struct {
+	Items []*Item `json:"items"`
+}
+
+// NewQueue loads the queue from path and the signing secret from keyPath,
+// creating the key on first use. Items that were being published when the
+// plugin stopped are marked failed rather than retried, since the post may
+// already be live.
+func NewQueue(path, keyPath string) (*Queue, error) {
+	secret, err := loadSecret(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	q := &Queue{path: path, secret: secret}
+
+	raw, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, fmt.Errorf("read approval queue failed: %w", err)
+	default:
+		var f queueFile
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return nil, fmt.Errorf("parse approval queue failed: %w", err)
+		}
+		q.items = f.Items
+	}
+
+	for _, it := range q.items {
+		if it.Status == StatusPublishing {
+			it.Status = StatusFailed
+			it.Reason = "插件重启时发布被中断，请到小红书确认是否已发出"
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q, q.saveLocked()
+}
+
+// Title, Content, Tags, Images and Mentions read the preview from Args, the
+// same map that is sent to the engine.
+func (it Item) Title() string      { return stringArg(it.Args, "title") }
+func (it Item) Content() string    { return stringArg(it.Args, "content") }
+func (it Item) Tags() []string     { return stringsArg(it.Args, "tags") }
+func (it Item) Images() []string   { return stringsArg(it.Args, "images") }
+func (it Item) Mentions() []string { return stringsArg(it.Args, "mentions") }
+
+// Enqueue adds a publication for review. Only the publish_content keys are
+// kept from args; the result is what will be sent to the engine.
+func (q *Queue) Enqueue(command string, args map[string]any) (Item, error) {
+	kept, err := publishArgs(args)
+	if err != nil {
+		return Item{}, err
+	}
+	id, err := newID()
+	if err != nil {
+		return Item{}, fmt.Errorf("generate publication id failed: %w", err)
+	}
+	it := &Item{
+		ID:        id,
+		Command:   command,
+		Args:      kept,
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.items = append(q.items, it)
+	return *it, q.saveLocked()
+}
+
+// List returns all items, newest first.
+func (q *Queue) List() []Item {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	list := make([]Item, 0, len(q.items))
+	for _, it := range q.items {
+		list = append(list, *it)
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list
+}
+
+// Sign returns the signature that authorizes decision on it. The signature
+// covers a digest of the item's Args, so editing the queue file after the
+// owner saw the preview invalidates it.
+func (q *Queue) Sign(it Item, decision string) string {
+	return q.mac(it.ID + ":" + decision + ":" + argsDigest(it.Args))
+}
+
+// PageToken is the token that opens the review page.
+func (q *Queue) PageToken() string {
+	return q.mac(":page")
+}
+
+func (q *Queue) mac(msg string) string {
+	mac := hmac.New(sha256.New, q.secret)
+	mac.Write([]byte(msg))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// argsDigest hashes args as canonical JSON; encoding/json sorts map keys.
+func argsDigest(args map[string]any) string {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// Decide applies a signed owner decision to a pending item. Approved items
+// move to publishing; the caller sends them to the engine and reports back
+// with Finish.
+func (q *Queue) Decide(id, decision, sig, reason string) (Item, error) {
+	if decision != DecisionApprove && decision != DecisionReject {
+		return Item{}, ErrBadDecision
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	it := q.findLocked(id)
+	if it == nil {
+		return Item{}, ErrNotFound
+	}
+	if !hmac.Equal([]byte(sig), []byte(q.Sign(*it, decision))) {
+		return Item{}, ErrBadSig
+	}
+	if it.Status != StatusPending {
+		return *it, ErrDecided
+	}
+
+	now := time.Now()
+	it.DecidedAt = &now
+	if decision == DecisionApprove {
+		it.Status = StatusPublishing
+	} else {
+		it.Status = StatusRejected
+		it.Reason = reason
+	}
+	return *it, q.saveLocked()
+}
+
+// Finish records the outcome of publishing an approved item.
+func (q *Queue) Finish(id string, result map[string]any, publishErr error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	it := q.findLocked(id)
+	if it == nil {
+		return ErrNotFound
+	}
+	it.Result = result
+	if ok, _ := result["success"].(bool); publishErr == nil && ok {
+		it.Status = StatusPublished
+	} else {
+		it.Status = StatusFailed
+		if publishErr != nil {
+			it.Reason = publishErr.Error()
+		} else {
+			it.Reason = fmt.Sprintf("%v", result["details"])
+		}
+	}
+	return q.saveLocked()
+}
+
+func (q *Queue) findLocked(id string) *Item {
+	for _, it := range q.items {
+		if it.ID == id {
+			return it
+		}
+	}
+	return nil
+}
+
+func (q *Queue) saveLocked() error {
+	raw, err := json.MarshalIndent(queueFile{Items: q.items}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(q.path), 0o755); err != nil {
+		return fmt.Errorf("create approval dir failed: %w", err)
+	}
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("write approval queue failed: %w", err)
+	}
+	return os.Rename(tmp, q.path)
+}
+
+// loadSecret reads the signing key, or creates it with owner-only permissions.
+func loadSecret(keyPath string) ([]byte, error) {
+	raw, err := os.ReadFile(keyPath)
+	if err == nil {
+		secret, err := hex.DecodeString(strings.TrimSpace(string(raw)))
+		if err != nil || len(secret) == 0 {
+			return nil, fmt.Errorf("parse approval key %s failed", keyPath)
+		}
+		return secret, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read approval key failed: %w", err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generate approval secret failed: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(keyPath), 0o700); err != nil {
+		return nil, fmt.Errorf("create approval key dir failed: %w", err)
+	}
+	if err := os.WriteFile(keyPath, []byte(hex.EncodeToString(secret)+"\n"), 0o600); err != nil {
+		return nil, fmt.Errorf("write approval key failed: %w", err)
+	}
+	return secret, nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// publishArgs keeps the publish_content keys of args. Values of the wrong type
+// are rejected rather than dropped, so the preview cannot skip anything the
+// engine would read.
+func publishArgs(args map[string]any) (map[string]any, error) {
+	kept := make(map[string]any, len(publishTextKeys)+len(publishListKeys))
+	for _, k := range publishTextKeys {
+		v, ok := args[k]
+		if !ok {
+			continue
+		}
+		if _, ok := v.(string); !ok {
+			return nil, fmt.Errorf("%w: %s must be a string", ErrBadArgs, k)
+		}
+		kept[k] = v
+	}
+	for _, k := range publishListKeys {
+		v, ok := args[k]
+		if !ok {
+			continue
+		}
+		var list []any
+		switch raw := v.(type) {
+		case []any:
+			list = raw
+		case []string:
+			for _, s := range raw {
+				list = append(list, s)
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s must be a list of strings", ErrBadArgs, k)
+		}
+		for _, e := range list {
+			if _, ok := e.(string); !ok {
+				return nil, fmt.Errorf("%w: %s must be a list of strings", ErrBadArgs, k)
+			}
+		}
+		kept[k] = list
+	}
+	return kept, nil
+}
+
+func stringArg(args map[string]any, key string) string {
+	s, _ := args[key].(string)
+	return s
+}
+
+func stringsArg(args map[string]any, key string) []string {
+	raw, _ := args[key].([]any)
+	list := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
@@ -0,0 +1,169 @@
+package approval
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestQueue(t *testing.T) (*Queue, string) {
+	t.Helper()
+	dir := t.TempDir()
+	q, err := NewQueue(filepath.Join(dir, "queue.json"), filepath.Join(dir, "owner", "approval.key"))
+	if err != nil {
+		t.Fatalf("NewQueue: %v", err)
+	}
+	return q, dir
+}
+
+func enqueue(t *testing.T, q *Queue) Item {
+	t.Helper()
+	it, err := q.Enqueue("publish_content", map[string]any{"title": "晒太阳", "content": "今天好暖和"})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	return it
+}
+
+func TestDecideRejectsWrongSignature(t *testing.T) {
+	q, _ := newTestQueue(t)
+	it := enqueue(t, q)
+
+	cases := map[string]string{
+		"empty":          "",
+		"other decision": q.Sign(it, DecisionReject),
+		"other item":     q.Sign(Item{ID: "deadbeef", Args: it.Args}, DecisionApprove),
+		"page token":     q.PageToken(),
+	}
+	for name, sig := range cases {
+		if _, err := q.Decide(it.ID, DecisionApprove, sig, ""); !errors.Is(err, ErrBadSig) {
+			t.Errorf("%s: err = %v, want ErrBadSig", name, err)
+		}
+	}
+	if got := q.List()[0].Status; got != StatusPending {
+		t.Errorf("status = %s, want pending", got)
+	}
+}
+
+func TestDecideTwice(t *testing.T) {
+	q, _ := newTestQueue(t)
+	it := enqueue(t, q)
+
+	got, err := q.Decide(it.ID, DecisionApprove, q.Sign(it, DecisionApprove), "")
+	if err != nil {
+		t.Fatalf("first Decide: %v", err)
+	}
+	if got.Status != StatusPublishing || got.DecidedAt == nil {
+		t.Fatalf("after approve: status = %s, decidedAt = %v", got.Status, got.DecidedAt)
+	}
+
+	if _, err := q.Decide(it.ID, DecisionApprove, q.Sign(it, DecisionApprove), ""); !errors.Is(err, ErrDecided) {
+		t.Errorf("second approve: err = %v, want ErrDecided", err)
+	}
+	if _, err := q.Decide(it.ID, DecisionReject, q.Sign(it, DecisionReject), ""); !errors.Is(err, ErrDecided) {
+		t.Errorf("reject after approve: err = %v, want ErrDecided", err)
+	}
+}
+
+func TestDecideReject(t *testing.T) {
+	q, _ := newTestQueue(t)
+	it := enqueue(t, q)
+
+	got, err := q.Decide(it.ID, DecisionReject, q.Sign(it, DecisionReject), "标题太长")
+	if err != nil {
+		t.Fatalf("Decide: %v", err)
+	}
+	if got.Status != StatusRejected || got.Reason != "标题太长" {
+		t.Errorf("got status %s reason %q", got.Status, got.Reason)
+	}
+
+	if _, err := q.Decide(it.ID, "publish", q.Sign(it, "publish"), ""); !errors.Is(err, ErrBadDecision) {
+		t.Errorf("unknown decision: err = %v, want ErrBadDecision", err)
+	}
+	if _, err := q.Decide("missing", DecisionReject, q.Sign(Item{ID: "missing"}, DecisionReject), ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing item: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestEnqueueKeepsOnlyPublishArgs(t *testing.T) {
+	q, _ := newTestQueue(t)
+	it, err := q.Enqueue("publish_content", map[string]any{
+		"title":       "晒太阳",
+		"content":     "今天好暖和",
+		"tags":        []any{"猫"},
+		"schedule_at": "2026-10-20T08:00:00+08:00",
+		"draft":       false,
+	})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if _, ok := it.Args["schedule_at"]; ok {
+		t.Errorf("args kept schedule_at: %v", it.Args)
+	}
+	if len(it.Args) != 3 || it.Title() != "晒太阳" || len(it.Tags()) != 1 {
+		t.Errorf("args = %v", it.Args)
+	}
+
+	if _, err := q.Enqueue("publish_content", map[string]any{"title": "x", "tags": []any{"猫", 1}}); !errors.Is(err, ErrBadArgs) {
+		t.Errorf("non-string tag: err = %v, want ErrBadArgs", err)
+	}
+}
+
+func TestDecideRejectsEditedArgs(t *testing.T) {
+	q, dir := newTestQueue(t)
+	it := enqueue(t, q)
+	sig := q.Sign(it, DecisionApprove)
+
+	path := filepath.Join(dir, "queue.json")
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(raw), "今天好暖和", "点击链接领红包", 1)
+	if err := os.WriteFile(path, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := NewQueue(path, filepath.Join(dir, "owner", "approval.key"))
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if _, err := reloaded.Decide(it.ID, DecisionApprove, sig, ""); !errors.Is(err, ErrBadSig) {
+		t.Errorf("Decide after editing args: err = %v, want ErrBadSig", err)
+	}
+}
+
+func TestSecretStaysOutOfQueueFile(t *testing.T) {
+	q, dir := newTestQueue(t)
+	it := enqueue(t, q)
+	sig := q.Sign(it, DecisionApprove)
+
+	raw, err := os.ReadFile(filepath.Join(dir, "queue.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := os.ReadFile(filepath.Join(dir, "owner", "approval.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), strings.TrimSpace(string(key))) || strings.Contains(string(raw), "secret") {
+		t.Errorf("queue file contains the signing secret:\n%s", raw)
+	}
+	info, err := os.Stat(filepath.Join(dir, "owner", "approval.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("key file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A reloaded queue reuses the key, so earlier signatures stay valid.
+	reloaded, err := NewQueue(filepath.Join(dir, "queue.json"), filepath.Join(dir, "owner", "approval.key"))
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if _, err := reloaded.Decide(it.ID, DecisionApprove, sig, ""); err != nil {
+		t.Errorf("Decide after reload: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Package audit keeps an append-only log of sensitive pet actions for the owner.
+// The log is only written to local disk and is never exposed through MCP tools.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry is one audited action.
+type Entry struct {
+	Time    time.Time      `json:"time"`
+	Command string         `json:"command"`
+	Args    map[string]any `json:"args,omitempty"`
+	OK      bool           `json:"ok"`
+	Detail  string         `json:"detail,omitempty"`
+}
+
+// Logger appends entries as JSON lines to a file.
+type Logger struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewLogger(path string) *Logger {
+	return &Logger{path: path}
+}
+
+// Record appends e to the log, filling in the time if unset.
+func (l *Logger) Record(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("create audit dir failed: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log failed: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -6,12 +6,68 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 type Config struct {
 	OwnerUserID       string
 	MCPBaseURL        string
+	Vision            VisionConfig
+	Replies           RepliesConfig
+	AuditPath         string
+	Quota             QuotaConfig
+	Messages          MessagesConfig
+	Confirm           ConfirmConfig
+	Approval          ApprovalConfig
+}
+
+// VisionConfig controls the images attached to tool results for multimodal models.
+type VisionConfig struct {
+	MaxImages         int
+	MaxImageDimension int
+}
+
+// RepliesConfig controls tracking of replies to the pet's own comments.
+type RepliesConfig struct {
+	StorePath     string
+	CheckInterval time.Duration
+}
+
+// QuotaConfig rate limits engine commands, keyed by command name.
+type QuotaConfig struct {
+	Path   string
+	Limits map[string]QuotaLimit
+}
+
+// QuotaLimit caps successful runs of one command; zero means no cap.
+type QuotaLimit struct {
+	PerHour int `json:"per_hour"`
+	PerDay  int `json:"per_day"`
+}
+
+// MessagesConfig controls direct messages. They are off unless explicitly
+// enabled, and by default the pet may only message the owner.
+type MessagesConfig struct {
+	Enabled   bool
+	OwnerOnly bool
+}
+
+// ConfirmConfig controls the one-time codes the owner hands back to approve
+// destructive actions such as deleting a note.
+type ConfirmConfig struct {
+	TTL time.Duration
+}
+
+// ApprovalConfig controls the queue where the pet's publications wait for the
+// owner's review, and the local page the owner reviews them on. OwnerDir holds
+// the signing key and the review page link; it lives outside the project tree
+// so the pet does not find them among its working files.
+type ApprovalConfig struct {
+	Path     string
+	Listen   string
+	OwnerDir string
 }
 
 type fileConfig struct {
@@ -21,6 +77,33 @@ type fileConfig struct {
 	MCP struct {
 		BaseURL string `json:"base_url"`
 	} `json:"mcp"`
+	Vision struct {
+		MaxImages         int `json:"max_images"`
+		MaxImageDimension int `json:"max_image_dimension"`
+	} `json:"vision"`
+	Replies struct {
+		StorePath            string `json:"store_path"`
+		CheckIntervalMinutes int    `json:"check_interval_minutes"`
+	} `json:"replies"`
+	Audit struct {
+		Path string `json:"path"`
+	} `json:"audit"`
+	Quota struct {
+		Path   string                `json:"path"`
+		Limits map[string]QuotaLimit `json:"limits"`
+	} `json:"quota"`
+	Messages struct {
+		Enabled   bool  `json:"enabled"`
+		OwnerOnly *bool `json:"owner_only"`
+	} `json:"messages"`
+	Confirm struct {
+		TTLMinutes int `json:"ttl_minutes"`
+	} `json:"confirm"`
+	Approval struct {
+		Path     string `json:"path"`
+		Listen   string `json:"listen"`
+		OwnerDir string `json:"owner_dir"`
+	} `json:"approval"`
 }
 
 func Load(path string) (*Config, error) {
@@ -43,11 +126,77 @@ func Load(path string) (*Config, error) {
 	cfg := &Config{
 		OwnerUserID: strings.TrimSpace(fc.Owner.UserID),
 		MCPBaseURL:  strings.TrimRight(strings.TrimSpace(fc.MCP.BaseURL), "/"),
+		Vision: VisionConfig{
+			MaxImages:         fc.Vision.MaxImages,
+			MaxImageDimension: fc.Vision.MaxImageDimension,
+		},
+		Replies: RepliesConfig{
+			StorePath:     strings.TrimSpace(fc.Replies.StorePath),
+			CheckInterval: time.Duration(fc.Replies.CheckIntervalMinutes) * time.Minute,
+		},
+		AuditPath: strings.TrimSpace(fc.Audit.Path),
+		Quota: QuotaConfig{
+			Path:   strings.TrimSpace(fc.Quota.Path),
+			Limits: fc.Quota.Limits,
+		},
+		Messages: MessagesConfig{
+			Enabled:   fc.Messages.Enabled,
+			OwnerOnly: fc.Messages.OwnerOnly == nil || *fc.Messages.OwnerOnly,
+		},
+		Confirm: ConfirmConfig{
+			TTL: time.Duration(fc.Confirm.TTLMinutes) * time.Minute,
+		},
+		Approval: ApprovalConfig{
+			Path:     strings.TrimSpace(fc.Approval.Path),
+			Listen:   strings.TrimSpace(fc.Approval.Listen),
+			OwnerDir: strings.TrimSpace(fc.Approval.OwnerDir),
+		},
 	}
 
 	if cfg.MCPBaseURL == "" {
 		cfg.MCPBaseURL = "http://127.0.0.1:18060"
 	}
+	if cfg.Vision.MaxImages <= 0 {
+		cfg.Vision.MaxImages = 4
+	}
+	if cfg.Vision.MaxImageDimension <= 0 {
+		cfg.Vision.MaxImageDimension = 768
+	}
+	if cfg.Replies.StorePath == "" {
+		cfg.Replies.StorePath = "data/replies.json"
+	}
+	if cfg.Replies.CheckInterval <= 0 {
+		cfg.Replies.CheckInterval = 15 * time.Minute
+	}
+	if cfg.Quota.Path == "" {
+		cfg.Quota.Path = "data/quota.json"
+	}
+	if cfg.Quota.Limits == nil {
+		cfg.Quota.Limits = map[string]QuotaLimit{}
+	}
+	if _, ok := cfg.Quota.Limits["follow_user"]; !ok {
+		cfg.Quota.Limits["follow_user"] = QuotaLimit{PerHour: 10, PerDay: 50}
+	}
+	if cfg.Confirm.TTL <= 0 {
+		cfg.Confirm.TTL = 30 * time.Minute
+	}
+	if cfg.Approval.Path == "" {
+		cfg.Approval.Path = "data/publish_queue.json"
+	}
+	if cfg.Approval.Listen == "" {
+		cfg.Approval.Listen = "127.0.0.1:18070"
+	}
+	if cfg.Approval.OwnerDir == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("approval.owner_dir is required: %w", err)
+		}
+		cfg.Approval.OwnerDir = filepath.Join(dir, "xiaohongshu-ai-pet")
+	}
+	if cfg.AuditPath == "" {
+		// The audit log lives with the owner, outside the project tree the pet can reach.
+		cfg.AuditPath = filepath.Join(cfg.Approval.OwnerDir, "owner_audit.jsonl")
+	}
 	if cfg.OwnerUserID == "" {
 		return nil, errors.New("owner.user_id is required (must be the owner account user_id, not the pet account)")
 	}
@@ -0,0 +1,107 @@
+// Package confirm issues one-time codes that the owner must hand back before
+// the pet may run a destructive command. Codes only live in the plugin's
+// memory and are shown on the owner's token-protected review page; they are
+// never returned through MCP tools, logged, or written into the project tree.
+package confirm
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Pending is a code waiting for the owner.
+type Pending struct {
+	Code      string    `json:"code"`
+	Command   string    `json:"command"`
+	Target    string    `json:"target"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Store keeps pending codes in memory. Codes do not survive a restart.
+type Store struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	pending map[string]Pending // keyed by command and target
+}
+
+func NewStore(ttl time.Duration) *Store {
+	return &Store{
+		ttl:     ttl,
+		pending: make(map[string]Pending),
+	}
+}
+
+// Issue creates a code for running command on target, replacing any earlier
+// code for the same action.
+func (s *Store) Issue(command, target string) (Pending, error) {
+	code, err := newCode()
+	if err != nil {
+		return Pending{}, fmt.Errorf("generate confirm code failed: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.pruneLocked(now)
+	p := Pending{Code: code, Command: command, Target: target, ExpiresAt: now.Add(s.ttl)}
+	s.pending[key(command, target)] = p
+	return p, nil
+}
+
+// List returns the codes still waiting for the owner, soonest to expire first.
+func (s *Store) List() []Pending {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked(time.Now())
+	list := make([]Pending, 0, len(s.pending))
+	for _, p := range s.pending {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ExpiresAt.Before(list[j].ExpiresAt) })
+	return list
+}
+
+// Redeem consumes the code for command on target. It reports false when no
+// code was issued for exactly this action, the code is wrong, or it expired.
+// A wrong code also voids the pending one, so codes cannot be guessed.
+func (s *Store) Redeem(command, target, code string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked(time.Now())
+	k := key(command, target)
+	p, ok := s.pending[k]
+	if !ok {
+		return false
+	}
+	delete(s.pending, k)
+	return code != "" && p.Code == code
+}
+
+func (s *Store) pruneLocked(now time.Time) {
+	for k, p := range s.pending {
+		if !now.Before(p.ExpiresAt) {
+			delete(s.pending, k)
+		}
+	}
+}
+
+func key(command, target string) string {
+	return command + "\x00" + target
+}
+
+// newCode returns a random six digit code.
+func newCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,165 @@
+// Package quota rate limits pet actions that the platform watches closely, such
+// as follows. Usage is kept in a JSON file so limits survive restarts.
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Limit caps how many times a command may succeed per hour and per day.
+// Zero means no cap for that window.
+type Limit struct {
+	PerHour int
+	PerDay  int
+}
+
+// Limiter checks and records command usage against per-command limits.
+type Limiter struct {
+	path   string
+	limits map[string]Limit
+
+	mu   sync.Mutex
+	used map[string][]time.Time
+}
+
+// NewLimiter loads recorded usage from path; a missing file starts empty.
+func NewLimiter(path string, limits map[string]Limit) (*Limiter, error) {
+	l := &Limiter{
+		path:   path,
+		limits: limits,
+		used:   make(map[string][]time.Time),
+	}
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read quota state failed: %w", err)
+	}
+	if err := json.Unmarshal(raw, &l.used); err != nil {
+		return nil, fmt.Errorf("parse quota state failed: %w", err)
+	}
+	return l, nil
+}
+
+// Reservation is a slot taken by Allow. Release it when the run turned out
+// not to count, so the slot goes back to the pool.
+type Reservation struct {
+	command string
+	at      time.Time
+}
+
+// Allow reports whether command may run now and, if so, reserves a slot for
+// it in the same critical section, so concurrent calls cannot all pass the
+// check and overshoot the cap. The returned error says which limit was hit
+// and when the next slot frees up.
+func (l *Limiter) Allow(command string) (Reservation, error) {
+	limit, ok := l.limits[command]
+	if !ok {
+		return Reservation{}, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	used := l.pruneLocked(command, now)
+
+	if err := checkWindow(used, now, time.Hour, limit.PerHour, "每小时"); err != nil {
+		return Reservation{}, err
+	}
+	if err := checkWindow(used, now, 24*time.Hour, limit.PerDay, "每天"); err != nil {
+		return Reservation{}, err
+	}
+	l.used[command] = append(used, now)
+	return Reservation{command: command, at: now}, l.saveLocked()
+}
+
+// Release returns a reserved slot, for runs that failed or changed nothing.
+func (l *Limiter) Release(r Reservation) error {
+	if r.command == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	used := l.used[r.command]
+	for i, t := range used {
+		if t.Equal(r.at) {
+			l.used[r.command] = append(used[:i], used[i+1:]...)
+			return l.saveLocked()
+		}
+	}
+	return nil
+}
+
+// Record counts one successful run of command that did not go through Allow.
+// Only commands with a limit are kept.
+func (l *Limiter) Record(command string) error {
+	if _, ok := l.limits[command]; !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.used[command] = append(l.pruneLocked(command, now), now)
+	return l.saveLocked()
+}
+
+// pruneLocked drops usage older than a day, the longest window.
+func (l *Limiter) pruneLocked(command string, now time.Time) []time.Time {
+	used := l.used[command]
+	kept := used[:0]
+	for _, t := range used {
+		if now.Sub(t) < 24*time.Hour {
+			kept = append(kept, t)
+		}
+	}
+	l.used[command] = kept
+	return kept
+}
+
+// checkWindow fails when max runs already happened within window before now.
+func checkWindow(used []time.Time, now time.Time, window time.Duration, max int, label string) error {
+	if max <= 0 {
+		return nil
+	}
+
+	var inWindow []time.Time
+	for _, t := range used {
+		if now.Sub(t) < window {
+			inWindow = append(inWindow, t)
+		}
+	}
+	if len(inWindow) < max {
+		return nil
+	}
+
+	// used is in recording order, so the slot frees when the oldest counted run ages out
+	wait := inWindow[len(inWindow)-max].Add(window).Sub(now).Round(time.Minute)
+	return fmt.Errorf("已达到%s %d 次的上限，约 %v 后可再试", label, max, wait)
+}
+
+func (l *Limiter) saveLocked() error {
+	raw, err := json.MarshalIndent(l.used, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("create quota dir failed: %w", err)
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("write quota state failed: %w", err)
+	}
+	return os.Rename(tmp, l.path)
+}
@@ -0,0 +1,133 @@
+package quota
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckWindow(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+
+	tests := []struct {
+		name     string
+		used     []time.Time
+		window   time.Duration
+		max      int
+		wantErr  bool
+		wantWait string
+	}{
+		{name: "no cap", used: []time.Time{ago(time.Minute), ago(2 * time.Minute)}, window: time.Hour, max: 0},
+		{name: "empty", window: time.Hour, max: 1},
+		{name: "under cap", used: []time.Time{ago(10 * time.Minute)}, window: time.Hour, max: 2},
+		{
+			name:     "at cap waits for oldest in window",
+			used:     []time.Time{ago(50 * time.Minute), ago(10 * time.Minute)},
+			window:   time.Hour,
+			max:      2,
+			wantErr:  true,
+			wantWait: "10m0s",
+		},
+		{
+			name:    "runs outside the window do not count",
+			used:    []time.Time{ago(2 * time.Hour), ago(61 * time.Minute), ago(5 * time.Minute)},
+			window:  time.Hour,
+			max:     2,
+			wantErr: false,
+		},
+		{
+			name:     "over cap waits until enough runs age out",
+			used:     []time.Time{ago(40 * time.Minute), ago(30 * time.Minute), ago(20 * time.Minute)},
+			window:   time.Hour,
+			max:      2,
+			wantErr:  true,
+			wantWait: "30m0s",
+		},
+		{
+			name:     "day window",
+			used:     []time.Time{ago(23 * time.Hour), ago(time.Hour)},
+			window:   24 * time.Hour,
+			max:      2,
+			wantErr:  true,
+			wantWait: "1h0m0s",
+		},
+		{
+			name:     "wait rounds to the minute",
+			used:     []time.Time{ago(59*time.Minute + 20*time.Second)},
+			window:   time.Hour,
+			max:      1,
+			wantErr:  true,
+			wantWait: "1m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkWindow(tt.used, now, tt.window, tt.max, "每小时")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "约 "+tt.wantWait+" 后") {
+				t.Errorf("err = %q, want wait %s", err, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestAllowReservesUnderConcurrency(t *testing.T) {
+	l, err := NewLimiter(filepath.Join(t.TempDir(), "quota.json"), map[string]Limit{"follow_user": {PerHour: 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	allowed := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := l.Allow("follow_user"); err == nil {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 3 {
+		t.Errorf("allowed = %d, want 3", allowed)
+	}
+}
+
+func TestReleaseFreesSlot(t *testing.T) {
+	l, err := NewLimiter(filepath.Join(t.TempDir(), "quota.json"), map[string]Limit{"follow_user": {PerDay: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slot, err := l.Allow("follow_user")
+	if err != nil {
+		t.Fatalf("first Allow: %v", err)
+	}
+	if _, err := l.Allow("follow_user"); err == nil {
+		t.Fatal("second Allow passed while the slot was reserved")
+	}
+	if err := l.Release(slot); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if _, err := l.Allow("follow_user"); err != nil {
+		t.Errorf("Allow after Release: %v", err)
+	}
+
+	if _, err := l.Allow("search_feeds"); err != nil {
+		t.Errorf("unlimited command: %v", err)
+	}
+	if err := l.Release(Reservation{}); err != nil {
+		t.Errorf("Release of an empty reservation: %v", err)
+	}
+}
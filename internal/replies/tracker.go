@@ -0,0 +1,355 @@
+// Package replies remembers the comments the pet has posted and finds replies
+// other users left under them, so the pet can keep conversations going.
+package replies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// maxTracked caps how many posted comments are remembered.
+	maxTracked = 200
+	// trackWindow is how long a posted comment keeps being checked for replies.
+	trackWindow = 3 * 24 * time.Hour
+	// checkBatch is how many comments one check opens; every check costs a page load.
+	checkBatch = 5
+	// threadReplyLimit is how many replies are read per thread.
+	threadReplyLimit = 50
+	// answeredRetention is how long answered replies are kept after they were
+	// found. It must exceed trackWindow: a reply is found no earlier than its
+	// pet comment was posted, so once it is pruned that comment is no longer
+	// checked and the reply cannot come back as new.
+	answeredRetention = 2 * trackWindow
+	// unansweredRetention is how long a reply waits for the pet before it is
+	// too stale to answer and is dropped. By the same argument as above it
+	// must be at least trackWindow.
+	unansweredRetention = trackWindow
+)
+
+// Executor runs an engine command, as implemented by xhs.Client.
+type Executor interface {
+	Execute(ctx context.Context, command string, args map[string]any) (map[string]any, int, error)
+}
+
+// PostedComment is a comment or reply the pet posted.
+type PostedComment struct {
+	CommentID string    `json:"comment_id"`
+	FeedID    string    `json:"feed_id"`
+	XsecToken string    `json:"xsec_token"`
+	Content   string    `json:"content"`
+	PostedAt  time.Time `json:"posted_at"`
+	CheckedAt time.Time `json:"checked_at,omitempty"`
+}
+
+// Reply is a reply someone left under one of the pet's comments.
+type Reply struct {
+	ReplyID        string    `json:"reply_id"`
+	FeedID         string    `json:"feed_id"`
+	XsecToken      string    `json:"xsec_token"`
+	UserID         string    `json:"user_id"`
+	Nickname       string    `json:"nickname"`
+	Content        string    `json:"content"`
+	CreateTime     int64     `json:"create_time"`
+	PetCommentID   string    `json:"pet_comment_id"`
+	PetComment     string    `json:"pet_comment"`
+	ThreadRootID   string    `json:"thread_root_id"`
+	ThreadRootText string    `json:"thread_root_text"`
+	FoundAt        time.Time `json:"found_at"`
+	Answered       bool      `json:"answered"`
+}
+
+type state struct {
+	Posted  []PostedComment `json:"posted"`
+	Replies []Reply         `json:"replies"`
+}
+
+// Tracker stores posted comments and their replies in a JSON file.
+type Tracker struct {
+	path   string
+	engine Executor
+
+	mu sync.Mutex
+	st state
+}
+
+// NewTracker loads the tracker state from path; a missing file starts empty.
+func NewTracker(path string, engine Executor) (*Tracker, error) {
+	t := &Tracker{path: path, engine: engine}
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read replies state failed: %w", err)
+	}
+	if err := json.Unmarshal(raw, &t.st); err != nil {
+		return nil, fmt.Errorf("parse replies state failed: %w", err)
+	}
+	return t, nil
+}
+
+// RecordPosted remembers a comment the pet just posted.
+func (t *Tracker) RecordPosted(c PostedComment) error {
+	if c.CommentID == "" || c.FeedID == "" {
+		return nil
+	}
+	if c.PostedAt.IsZero() {
+		c.PostedAt = time.Now()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.st.Posted = append(t.st.Posted, c)
+	if len(t.st.Posted) > maxTracked {
+		t.st.Posted = t.st.Posted[len(t.st.Posted)-maxTracked:]
+	}
+	return t.saveLocked()
+}
+
+// Forget stops tracking a posted comment, e.g. after the pet deleted it.
+func (t *Tracker) Forget(commentID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	kept := t.st.Posted[:0]
+	for _, c := range t.st.Posted {
+		if c.CommentID != commentID {
+			kept = append(kept, c)
+		}
+	}
+	t.st.Posted = kept
+	return t.saveLocked()
+}
+
+// MarkAnswered marks the reply with replyID as answered by the pet.
+func (t *Tracker) MarkAnswered(replyID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range t.st.Replies {
+		if t.st.Replies[i].ReplyID == replyID && !t.st.Replies[i].Answered {
+			t.st.Replies[i].Answered = true
+			t.pruneLocked(time.Now())
+			return t.saveLocked()
+		}
+	}
+	return nil
+}
+
+// Unanswered returns the replies the pet has not answered yet, oldest first.
+func (t *Tracker) Unanswered() []Reply {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	var out []Reply
+	for _, r := range t.st.Replies {
+		if !r.Answered && now.Sub(r.FoundAt) <= unansweredRetention {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreateTime < out[j].CreateTime })
+	return out
+}
+
+// Check opens the threads of the least recently checked comments and records new replies.
+// It returns how many new replies were found.
+func (t *Tracker) Check(ctx context.Context) (int, error) {
+	due := t.dueComments(time.Now())
+
+	found := 0
+	for _, c := range due {
+		thread, err := t.fetchThread(ctx, c)
+		if err != nil {
+			log.Printf("check replies of comment %s failed: %v", c.CommentID, err)
+			continue
+		}
+		n, err := t.merge(c, thread)
+		if err != nil {
+			return found, err
+		}
+		found += n
+	}
+	return found, nil
+}
+
+// Run checks for replies every interval until ctx is done.
+// ready is consulted before each check, e.g. to skip while the pet is logged out.
+func (t *Tracker) Run(ctx context.Context, interval time.Duration, ready func() bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ready != nil && !ready() {
+				continue
+			}
+			if n, err := t.Check(ctx); err != nil {
+				log.Printf("check replies failed: %v", err)
+			} else if n > 0 {
+				log.Printf("found %d new replies to the pet", n)
+			}
+		}
+	}
+}
+
+// dueComments picks the comments inside the track window that were checked least recently.
+func (t *Tracker) dueComments(now time.Time) []PostedComment {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var due []PostedComment
+	for _, c := range t.st.Posted {
+		if now.Sub(c.PostedAt) <= trackWindow {
+			due = append(due, c)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].CheckedAt.Before(due[j].CheckedAt) })
+	if len(due) > checkBatch {
+		due = due[:checkBatch]
+	}
+	return due
+}
+
+// comment mirrors the engine's comment JSON, keeping only what the tracker needs.
+type comment struct {
+	ID         string `json:"id"`
+	Content    string `json:"content"`
+	CreateTime int64  `json:"createTime"`
+	UserInfo   struct {
+		UserID   string `json:"userId"`
+		Nickname string `json:"nickname"`
+	} `json:"userInfo"`
+	SubComments []comment `json:"subComments"`
+}
+
+func (t *Tracker) fetchThread(ctx context.Context, c PostedComment) (*comment, error) {
+	data, status, err := t.engine.Execute(ctx, "comment_thread", map[string]any{
+		"feed_id":    c.FeedID,
+		"xsec_token": c.XsecToken,
+		"comment_id": c.CommentID,
+		"limit":      threadReplyLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status >= 300 {
+		return nil, fmt.Errorf("engine returned status %d: %v", status, data["error"])
+	}
+
+	raw, err := json.Marshal(data["data"])
+	if err != nil {
+		return nil, err
+	}
+	var thread struct {
+		Comment comment `json:"comment"`
+	}
+	if err := json.Unmarshal(raw, &thread); err != nil {
+		return nil, fmt.Errorf("parse comment thread failed: %w", err)
+	}
+	return &thread.Comment, nil
+}
+
+// merge records the replies directly under the pet's comment that are new.
+func (t *Tracker) merge(c PostedComment, root *comment) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	own := make(map[string]bool, len(t.st.Posted))
+	for _, p := range t.st.Posted {
+		own[p.CommentID] = true
+	}
+	known := make(map[string]bool, len(t.st.Replies))
+	for _, r := range t.st.Replies {
+		known[r.ReplyID] = true
+	}
+
+	found := 0
+	if node := findComment(root, c.CommentID); node != nil {
+		for _, sub := range node.SubComments {
+			if own[sub.ID] || known[sub.ID] {
+				continue
+			}
+			t.st.Replies = append(t.st.Replies, Reply{
+				ReplyID:        sub.ID,
+				FeedID:         c.FeedID,
+				XsecToken:      c.XsecToken,
+				UserID:         sub.UserInfo.UserID,
+				Nickname:       sub.UserInfo.Nickname,
+				Content:        sub.Content,
+				CreateTime:     sub.CreateTime,
+				PetCommentID:   c.CommentID,
+				PetComment:     c.Content,
+				ThreadRootID:   root.ID,
+				ThreadRootText: root.Content,
+				FoundAt:        time.Now(),
+			})
+			found++
+		}
+	}
+
+	for i := range t.st.Posted {
+		if t.st.Posted[i].CommentID == c.CommentID {
+			t.st.Posted[i].CheckedAt = time.Now()
+		}
+	}
+	t.pruneLocked(time.Now())
+	return found, t.saveLocked()
+}
+
+// pruneLocked drops answered replies found more than answeredRetention ago
+// and unanswered ones found more than unansweredRetention ago, so the state
+// file does not grow forever.
+func (t *Tracker) pruneLocked(now time.Time) {
+	kept := t.st.Replies[:0]
+	for _, r := range t.st.Replies {
+		retention := unansweredRetention
+		if r.Answered {
+			retention = answeredRetention
+		}
+		if now.Sub(r.FoundAt) <= retention {
+			kept = append(kept, r)
+		}
+	}
+	t.st.Replies = kept
+}
+
+func findComment(node *comment, id string) *comment {
+	if node.ID == id {
+		return node
+	}
+	for i := range node.SubComments {
+		if found := findComment(&node.SubComments[i], id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) saveLocked() error {
+	raw, err := json.MarshalIndent(t.st, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return fmt.Errorf("create replies state dir failed: %w", err)
+	}
+	tmp := t.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("write replies state failed: %w", err)
+	}
+	return os.Rename(tmp, t.path)
+}
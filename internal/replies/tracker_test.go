@@ -0,0 +1,153 @@
+package replies
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeEngine serves comment_thread from a fixed set of threads keyed by comment ID.
+type fakeEngine struct {
+	threads map[string]string
+	calls   int
+}
+
+func (f *fakeEngine) Execute(ctx context.Context, command string, args map[string]any) (map[string]any, int, error) {
+	f.calls++
+	var data map[string]any
+	raw := `{"success": true, "data": {"comment": ` + f.threads[args["comment_id"].(string)] + `}}`
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, 0, err
+	}
+	return data, 200, nil
+}
+
+func newTestTracker(t *testing.T, engine Executor) *Tracker {
+	t.Helper()
+	tr, err := NewTracker(filepath.Join(t.TempDir(), "replies.json"), engine)
+	if err != nil {
+		t.Fatalf("NewTracker: %v", err)
+	}
+	return tr
+}
+
+func TestCheckFindsNewReplies(t *testing.T) {
+	engine := &fakeEngine{threads: map[string]string{
+		"pet1": `{"id": "root", "content": "你家猫好胖", "subComments": [
+			{"id": "pet1", "content": "是橘猫啦", "subComments": [
+				{"id": "r1", "content": "橘猫都这样", "createTime": 2, "userInfo": {"userId": "u1", "nickname": "小明"}},
+				{"id": "pet2", "content": "哈哈对", "createTime": 3},
+				{"id": "r2", "content": "多大了", "createTime": 1, "userInfo": {"userId": "u2", "nickname": "小红"}}
+			]}
+		]}`,
+	}}
+	tr := newTestTracker(t, engine)
+	now := time.Now()
+	if err := tr.RecordPosted(PostedComment{CommentID: "pet1", FeedID: "f1", XsecToken: "tok", Content: "是橘猫啦", PostedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.RecordPosted(PostedComment{CommentID: "pet2", FeedID: "f1", PostedAt: now.Add(-4 * 24 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := tr.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("found %d replies, want 2 (the pet's own reply is skipped)", n)
+	}
+	if engine.calls != 1 {
+		t.Errorf("engine calls = %d, want 1 (pet2 is outside the track window)", engine.calls)
+	}
+
+	got := tr.Unanswered()
+	if len(got) != 2 || got[0].ReplyID != "r2" || got[1].ReplyID != "r1" {
+		t.Fatalf("unanswered = %+v, want r2 then r1 by create time", got)
+	}
+	r := got[1]
+	if r.PetCommentID != "pet1" || r.PetComment != "是橘猫啦" || r.ThreadRootID != "root" || r.ThreadRootText != "你家猫好胖" || r.Nickname != "小明" || r.XsecToken != "tok" {
+		t.Errorf("reply context = %+v", r)
+	}
+
+	// A second check of the same thread must not record the replies again.
+	if n, err := tr.Check(context.Background()); err != nil || n != 0 {
+		t.Errorf("second Check = %d, %v; want 0, nil", n, err)
+	}
+	if len(tr.Unanswered()) != 2 {
+		t.Errorf("unanswered after second check = %d, want 2", len(tr.Unanswered()))
+	}
+}
+
+func TestMarkAnswered(t *testing.T) {
+	engine := &fakeEngine{threads: map[string]string{
+		"pet1": `{"id": "pet1", "subComments": [{"id": "r1", "content": "你好"}]}`,
+	}}
+	tr := newTestTracker(t, engine)
+	if err := tr.RecordPosted(PostedComment{CommentID: "pet1", FeedID: "f1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tr.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tr.MarkAnswered("r1"); err != nil {
+		t.Fatalf("MarkAnswered: %v", err)
+	}
+	if got := tr.Unanswered(); len(got) != 0 {
+		t.Errorf("unanswered = %+v, want none", got)
+	}
+
+	// Answered replies are still known, so they do not come back as new.
+	if n, err := tr.Check(context.Background()); err != nil || n != 0 {
+		t.Errorf("Check after answering = %d, %v; want 0, nil", n, err)
+	}
+
+	// Reloading from disk keeps the answered flag.
+	reloaded, err := NewTracker(tr.path, engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Unanswered(); len(got) != 0 {
+		t.Errorf("unanswered after reload = %+v, want none", got)
+	}
+}
+
+func TestPruneReplies(t *testing.T) {
+	now := time.Now()
+	tr := newTestTracker(t, &fakeEngine{})
+	tr.st.Replies = []Reply{
+		{ReplyID: "old-answered", Answered: true, FoundAt: now.Add(-answeredRetention - time.Hour)},
+		{ReplyID: "stale-open", FoundAt: now.Add(-unansweredRetention - time.Hour)},
+		{ReplyID: "answered", Answered: true, FoundAt: now.Add(-unansweredRetention - time.Hour)},
+		{ReplyID: "open", FoundAt: now.Add(-time.Hour)},
+	}
+
+	tr.pruneLocked(now)
+
+	var ids []string
+	for _, r := range tr.st.Replies {
+		ids = append(ids, r.ReplyID)
+	}
+	if len(ids) != 2 || ids[0] != "answered" || ids[1] != "open" {
+		t.Errorf("kept %v, want [answered open]", ids)
+	}
+	if answeredRetention <= trackWindow || unansweredRetention < trackWindow {
+		t.Errorf("retentions %v and %v must not be shorter than trackWindow %v", answeredRetention, unansweredRetention, trackWindow)
+	}
+}
+
+func TestUnansweredSkipsStaleReplies(t *testing.T) {
+	tr := newTestTracker(t, &fakeEngine{})
+	tr.st.Replies = []Reply{
+		{ReplyID: "stale", FoundAt: time.Now().Add(-unansweredRetention - time.Hour)},
+		{ReplyID: "fresh", FoundAt: time.Now()},
+	}
+
+	got := tr.Unanswered()
+	if len(got) != 1 || got[0].ReplyID != "fresh" {
+		t.Errorf("unanswered = %+v, want only fresh", got)
+	}
+}
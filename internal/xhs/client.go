@@ -24,16 +24,39 @@ type route struct {
 }
 
 var allowlist = map[string]route{
-	"check_login_status": {Method: http.MethodGet, Path: "/api/v1/login/status", QueryArg: true},
-	"my_profile":         {Method: http.MethodGet, Path: "/api/v1/user/me", QueryArg: true},
-	"list_feeds":         {Method: http.MethodGet, Path: "/api/v1/feeds/list", QueryArg: true},
-	"search_feeds":       {Method: http.MethodPost, Path: "/api/v1/feeds/search"},
-	"feed_detail":        {Method: http.MethodPost, Path: "/api/v1/feeds/detail"},
-	"user_profile":       {Method: http.MethodPost, Path: "/api/v1/user/profile"},
-	"publish_content":    {Method: http.MethodPost, Path: "/api/v1/publish"},
-	"publish_video":      {Method: http.MethodPost, Path: "/api/v1/publish_video"},
-	"post_comment":       {Method: http.MethodPost, Path: "/api/v1/feeds/comment"},
-	"reply_comment":      {Method: http.MethodPost, Path: "/api/v1/feeds/comment/reply"},
+	"check_login_status":  {Method: http.MethodGet, Path: "/api/v1/login/status", QueryArg: true},
+	"my_profile":          {Method: http.MethodGet, Path: "/api/v1/user/me", QueryArg: true},
+	"list_feeds":          {Method: http.MethodGet, Path: "/api/v1/feeds/list", QueryArg: true},
+	"search_feeds":        {Method: http.MethodPost, Path: "/api/v1/feeds/search"},
+	"search_suggest":      {Method: http.MethodGet, Path: "/api/v1/search/suggest", QueryArg: true},
+	"trending_keywords":   {Method: http.MethodGet, Path: "/api/v1/search/trending", QueryArg: true},
+	"topic_feeds":         {Method: http.MethodPost, Path: "/api/v1/topic/feeds"},
+	"feed_detail":         {Method: http.MethodPost, Path: "/api/v1/feeds/detail"},
+	"list_comments":       {Method: http.MethodPost, Path: "/api/v1/feeds/comments"},
+	"user_profile":        {Method: http.MethodPost, Path: "/api/v1/user/profile"},
+	"publish_content":     {Method: http.MethodPost, Path: "/api/v1/publish"},
+	"publish_video":       {Method: http.MethodPost, Path: "/api/v1/publish_video"},
+	"post_comment":        {Method: http.MethodPost, Path: "/api/v1/feeds/comment"},
+	"reply_comment":       {Method: http.MethodPost, Path: "/api/v1/feeds/comment/reply"},
+	"comment_thread":      {Method: http.MethodPost, Path: "/api/v1/feeds/comment/thread"},
+	"delete_comment":      {Method: http.MethodPost, Path: "/api/v1/feeds/comment/delete"},
+	"like_comment":        {Method: http.MethodPost, Path: "/api/v1/feeds/comment/like"},
+	"list_emojis":         {Method: http.MethodGet, Path: "/api/v1/feeds/comment/emojis", QueryArg: true},
+	"follow_user":         {Method: http.MethodPost, Path: "/api/v1/user/follow"},
+	"list_followers":      {Method: http.MethodGet, Path: "/api/v1/user/me/followers", QueryArg: true},
+	"list_followings":     {Method: http.MethodGet, Path: "/api/v1/user/me/followings", QueryArg: true},
+	"get_notifications":   {Method: http.MethodGet, Path: "/api/v1/notifications", QueryArg: true},
+	"list_conversations":  {Method: http.MethodGet, Path: "/api/v1/messages/conversations", QueryArg: true},
+	"read_conversation":   {Method: http.MethodPost, Path: "/api/v1/messages/conversation"},
+	"send_message":        {Method: http.MethodPost, Path: "/api/v1/messages/send"},
+	"list_my_notes":       {Method: http.MethodGet, Path: "/api/v1/notes/mine", QueryArg: true},
+	"delete_note":         {Method: http.MethodPost, Path: "/api/v1/notes/delete"},
+	"set_note_visibility": {Method: http.MethodPost, Path: "/api/v1/notes/visibility"},
+	"creator_stats":       {Method: http.MethodPost, Path: "/api/v1/creator/stats"},
+	"creator_stats_trend": {Method: http.MethodGet, Path: "/api/v1/creator/stats/trend", QueryArg: true},
+	"list_drafts":         {Method: http.MethodGet, Path: "/api/v1/drafts", QueryArg: true},
+	"publish_draft":       {Method: http.MethodPost, Path: "/api/v1/drafts/publish"},
+	"delete_draft":        {Method: http.MethodPost, Path: "/api/v1/drafts/delete"},
 }
 
 func NewClient(baseURL string, timeout time.Duration) *Client {
@@ -43,6 +66,9 @@ func NewClient(baseURL string, timeout time.Duration) *Client {
 	}
 }
 
+// Execute sends command to the engine. Errors after the request was sent,
+// including timeouts, are reported with http.StatusBadGateway: the engine may
+// have run the action anyway.
 func (c *Client) Execute(ctx context.Context, command string, args map[string]any) (map[string]any, int, error) {
 	rt, ok := allowlist[strings.ToLower(strings.TrimSpace(command))]
 	if !ok {
@@ -60,7 +86,7 @@ func (c *Client) Execute(ctx context.Context, command string, args map[string]an
 		}
 		q := u.Query()
 		for k, v := range args {
-			q.Set(k, fmt.Sprintf("%v", v))
+			q.Set(k, queryValue(v))
 		}
 		u.RawQuery = q.Encode()
 		req, err = http.NewRequestWithContext(ctx, rt.Method, u.String(), nil)
@@ -96,3 +122,16 @@ func (c *Client) Execute(ctx context.Context, command string, args map[string]an
 	return out, resp.StatusCode, nil
 }
 
+// queryValue formats a tool argument for a query string; lists become
+// comma-separated values.
+func queryValue(v any) string {
+	list, ok := v.([]any)
+	if !ok {
+		return fmt.Sprintf("%v", v)
+	}
+	parts := make([]string, 0, len(list))
+	for _, item := range list {
+		parts = append(parts, fmt.Sprintf("%v", item))
+	}
+	return strings.Join(parts, ",")
+}
@@ -7,7 +7,8 @@ import (
 )
 
 type browserConfig struct {
-	binPath string
+	binPath     string
+	userDataDir string
 }
 
 type Option func(*browserConfig)
@@ -18,6 +19,13 @@ func WithBinPath(binPath string) Option {
 	}
 }
 
+// WithUserDataDir 使用固定的浏览器配置目录，关闭浏览器后保留本地存储（如草稿箱）
+func WithUserDataDir(dir string) Option {
+	return func(c *browserConfig) {
+		c.userDataDir = dir
+	}
+}
+
 func NewBrowser(headless bool, options ...Option) *headless_browser.Browser {
 	cfg := &browserConfig{}
 	for _, opt := range options {
@@ -30,6 +38,9 @@ func NewBrowser(headless bool, options ...Option) *headless_browser.Browser {
 	if cfg.binPath != "" {
 		opts = append(opts, headless_browser.WithChromeBinPath(cfg.binPath))
 	}
+	if cfg.userDataDir != "" {
+		opts = append(opts, headless_browser.WithUserDataDir(cfg.userDataDir))
+	}
 
 	// 加载 cookies
 	cookiePath := cookies.GetCookiesFilePath()
@@ -0,0 +1,12 @@
+package configs
+
+import "os"
+
+// GetDraftProfilePath 草稿相关操作使用的浏览器配置目录
+// 创作中心网页端的草稿保存在浏览器本地，需要固定目录才能在多次操作之间保留，可通过环境变量 DRAFT_PROFILE_DIR 指定
+func GetDraftProfilePath() string {
+	if path := os.Getenv("DRAFT_PROFILE_DIR"); path != "" {
+		return path
+	}
+	return "draft_profile"
+}
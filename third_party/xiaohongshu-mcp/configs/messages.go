@@ -0,0 +1,36 @@
+package configs
+
+import "strings"
+
+var (
+	messagesEnabled = false
+
+	// messageAllowlist 非空时只能与名单中的用户收发私信
+	messageAllowlist = map[string]bool{}
+)
+
+// InitMessages 设置私信开关和允许名单；allowUserIDs 为空表示不限制对象。
+func InitMessages(enabled bool, allowUserIDs []string) {
+	messagesEnabled = enabled
+	messageAllowlist = map[string]bool{}
+	for _, id := range allowUserIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			messageAllowlist[id] = true
+		}
+	}
+}
+
+// MessagesEnabled 是否开启私信功能，默认关闭。
+func MessagesEnabled() bool {
+	return messagesEnabled
+}
+
+// MessageAllowlistMode 是否只允许与名单中的用户收发私信。
+func MessageAllowlistMode() bool {
+	return len(messageAllowlist) > 0
+}
+
+// MessageRecipientAllowed 是否允许与 userID 收发私信。
+func MessageRecipientAllowed(userID string) bool {
+	return !MessageAllowlistMode() || messageAllowlist[userID]
+}
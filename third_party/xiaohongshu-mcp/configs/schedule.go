@@ -0,0 +1,20 @@
+package configs
+
+import "os"
+
+// GetSchedulePath 本地定时发布任务的保存路径，可通过环境变量 PUBLISH_SCHEDULE_PATH 指定
+func GetSchedulePath() string {
+	if path := os.Getenv("PUBLISH_SCHEDULE_PATH"); path != "" {
+		return path
+	}
+	return "publish_schedule.json"
+}
+
+// GetScheduleImagesPath 本地定时发布任务的图片在添加任务时下载到这里，
+// 不放在系统临时目录，避免到点前被清理；可通过环境变量 PUBLISH_SCHEDULE_IMAGES_DIR 指定
+func GetScheduleImagesPath() string {
+	if path := os.Getenv("PUBLISH_SCHEDULE_IMAGES_DIR"); path != "" {
+		return path
+	}
+	return "publish_schedule_images"
+}
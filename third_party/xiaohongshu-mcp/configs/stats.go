@@ -0,0 +1,11 @@
+package configs
+
+import "os"
+
+// GetCreatorStatsPath 创作中心数据快照的保存路径，可通过环境变量 CREATOR_STATS_PATH 指定
+func GetCreatorStatsPath() string {
+	if path := os.Getenv("CREATOR_STATS_PATH"); path != "" {
+		return path
+	}
+	return "creator_stats.jsonl"
+}
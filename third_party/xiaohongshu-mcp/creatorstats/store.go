@@ -0,0 +1,98 @@
+// Package creatorstats 把创作中心的数据快照保存为本地时间序列，并计算一段时间内的变化
+package creatorstats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// Store 以 JSON Lines 保存数据快照，每行一份，只追加不修改
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewStore(path string) *Store {
+	if path == "" {
+		panic("path is required")
+	}
+	return &Store{path: path}
+}
+
+// Append 追加一份快照
+func (s *Store) Append(snapshot xiaohongshu.CreatorStats) error {
+	line, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return errors.Wrap(err, "创建数据目录失败")
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "打开数据文件失败")
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// Load 读取全部快照，按采集时间从旧到新；文件不存在时返回空列表
+func (s *Store) Load() ([]xiaohongshu.CreatorStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "打开数据文件失败")
+	}
+	defer f.Close()
+
+	var snapshots []xiaohongshu.CreatorStats
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var snapshot xiaohongshu.CreatorStats
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return nil, fmt.Errorf("解析数据文件第 %d 行失败: %w", line, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "读取数据文件失败")
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CollectedAt.Before(snapshots[j].CollectedAt)
+	})
+	return snapshots, nil
+}
+
+// Trend 读取全部快照并计算 since 以来的变化
+func (s *Store) Trend(since time.Time) (*Trend, error) {
+	snapshots, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return ComputeTrend(snapshots, since)
+}
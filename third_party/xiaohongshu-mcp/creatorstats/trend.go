@@ -0,0 +1,143 @@
+package creatorstats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	xhserrors "github.com/xpzouying/xiaohongshu-mcp/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// MetricDelta 一个指标在基准快照和最新快照中的值
+type MetricDelta struct {
+	From   float64 `json:"from"`
+	To     float64 `json:"to"`
+	Change float64 `json:"change"`
+}
+
+// NoteTrend 一篇笔记的数据变化
+type NoteTrend struct {
+	NoteID  string                 `json:"noteId,omitempty"`
+	Title   string                 `json:"title"`
+	New     bool                   `json:"new"`     // 基准快照中没有这篇笔记，From 均为 0
+	Metrics map[string]MetricDelta `json:"metrics"` // 累计数量（观看、点赞等）的变化
+	Rates   map[string]float64     `json:"rates"`   // 最新快照中的比率和平均值，不做相减
+}
+
+// Trend 两份快照之间的数据变化
+type Trend struct {
+	Since         time.Time              `json:"since"`
+	From          time.Time              `json:"from"` // 基准快照的采集时间
+	To            time.Time              `json:"to"`   // 最新快照的采集时间
+	Snapshots     int                    `json:"snapshots"`
+	BasePeriod    string                 `json:"basePeriod,omitempty"` // 基准快照中账号数据的统计周期
+	Period        string                 `json:"period,omitempty"`     // 最新快照中账号数据的统计周期
+	PeriodChanged bool                   `json:"periodChanged"`        // 两份快照的统计周期不同
+	Account       map[string]MetricDelta `json:"account"`              // 账号累计指标（总粉丝数）的变化
+	Window        map[string]float64     `json:"window"`               // 最新快照中 Period 统计周期内的账号数据，不做相减
+	Notes         []NoteTrend            `json:"notes"`
+}
+
+// cumulativeAccountMetrics 账号数据中的累计值，可以在两份快照之间相减；
+// 其余账号指标都是统计周期（如近7日）内的滚动值，两次相减并不是 since 以来的增长
+var cumulativeAccountMetrics = map[string]bool{"totalFollowers": true}
+
+// noteRateMetrics 笔记数据中的比率和平均值，不随时间累加，只报告最新值
+var noteRateMetrics = map[string]bool{"coverClickRate": true, "avgViewSeconds": true, "completionRate": true}
+
+// ComputeTrend 以 since 时刻的数据为基准，计算到最新快照的变化
+// 基准为 since 之前（含）最后一份快照；since 之前没有快照时用最早的一份。
+// 只对累计值计算变化，账号的滚动周期数据和笔记的比率只报告最新快照中的值
+// snapshots 需按采集时间从旧到新排列
+func ComputeTrend(snapshots []xiaohongshu.CreatorStats, since time.Time) (*Trend, error) {
+	if len(snapshots) == 0 {
+		return nil, xhserrors.ErrNoCreatorStats
+	}
+
+	base := snapshots[0]
+	for _, s := range snapshots {
+		if s.CollectedAt.After(since) {
+			break
+		}
+		base = s
+	}
+	latest := snapshots[len(snapshots)-1]
+
+	baseAccount, _ := splitMetrics(base.Account.Metrics(), cumulativeAccountMetrics)
+	account, window := splitMetrics(latest.Account.Metrics(), cumulativeAccountMetrics)
+	trend := &Trend{
+		Since:         since,
+		From:          base.CollectedAt,
+		To:            latest.CollectedAt,
+		Snapshots:     len(snapshots),
+		BasePeriod:    base.Period,
+		Period:        latest.Period,
+		PeriodChanged: base.Period != latest.Period,
+		Account:       diffMetrics(baseAccount, account),
+		Window:        window,
+		Notes:         make([]NoteTrend, 0, len(latest.Notes)),
+	}
+
+	baseNotes := make(map[string]xiaohongshu.NoteStats, len(base.Notes))
+	for _, n := range base.Notes {
+		baseNotes[noteKey(n)] = n
+	}
+	for _, n := range latest.Notes {
+		old, ok := baseNotes[noteKey(n)]
+		rates, counts := splitMetrics(n.Metrics(), noteRateMetrics)
+		_, oldCounts := splitMetrics(old.Metrics(), noteRateMetrics)
+		trend.Notes = append(trend.Notes, NoteTrend{
+			NoteID:  n.NoteID,
+			Title:   n.Title,
+			New:     !ok,
+			Metrics: diffMetrics(oldCounts, counts),
+			Rates:   rates,
+		})
+	}
+
+	// 观看增长最多的笔记在前
+	sort.SliceStable(trend.Notes, func(i, j int) bool {
+		return trend.Notes[i].Metrics["views"].Change > trend.Notes[j].Metrics["views"].Change
+	})
+	return trend, nil
+}
+
+// noteKey 用笔记 ID 区分笔记，页面没有 ID 时退回标题
+func noteKey(n xiaohongshu.NoteStats) string {
+	if n.NoteID != "" {
+		return "id:" + n.NoteID
+	}
+	return "title:" + n.Title
+}
+
+// splitMetrics 把指标分成 names 中的和其余的两组
+func splitMetrics(metrics map[string]float64, names map[string]bool) (in, rest map[string]float64) {
+	in = make(map[string]float64, len(names))
+	rest = make(map[string]float64, len(metrics))
+	for name, v := range metrics {
+		if names[name] {
+			in[name] = v
+		} else {
+			rest[name] = v
+		}
+	}
+	return in, rest
+}
+
+func diffMetrics(from, to map[string]float64) map[string]MetricDelta {
+	deltas := make(map[string]MetricDelta, len(to))
+	for name, v := range to {
+		deltas[name] = MetricDelta{
+			From:   from[name],
+			To:     v,
+			Change: round2(v - from[name]),
+		}
+	}
+	return deltas
+}
+
+// round2 保留两位小数，避免百分比相减出现 0.30000000000000004
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,106 @@
+package creatorstats
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xhserrors "github.com/xpzouying/xiaohongshu-mcp/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+func snapshotAt(day int, followers int64, notes ...xiaohongshu.NoteStats) xiaohongshu.CreatorStats {
+	return xiaohongshu.CreatorStats{
+		CollectedAt: time.Date(2025, 3, day, 9, 0, 0, 0, time.UTC),
+		Period:      "近7日",
+		Account:     xiaohongshu.AccountStats{TotalFollowers: followers, CoverClickRate: 8.1},
+		Notes:       notes,
+	}
+}
+
+func TestComputeTrend(t *testing.T) {
+	snapshots := []xiaohongshu.CreatorStats{
+		snapshotAt(1, 100, xiaohongshu.NoteStats{NoteID: "n1", Title: "散步", Views: 50}),
+		snapshotAt(3, 120,
+			xiaohongshu.NoteStats{NoteID: "n1", Title: "散步", Views: 80},
+			xiaohongshu.NoteStats{Title: "晒太阳", Views: 10},
+		),
+		snapshotAt(5, 150,
+			xiaohongshu.NoteStats{NoteID: "n1", Title: "散步", Views: 90},
+			xiaohongshu.NoteStats{Title: "晒太阳", Views: 60},
+			xiaohongshu.NoteStats{NoteID: "n3", Title: "新笔记", Views: 5},
+		),
+	}
+	snapshots[2].Account.CoverClickRate = 8.4
+
+	trend, err := ComputeTrend(snapshots, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, snapshots[1].CollectedAt, trend.From, "baseline is the last snapshot before since")
+	assert.Equal(t, snapshots[2].CollectedAt, trend.To)
+	assert.Equal(t, 3, trend.Snapshots)
+	assert.Equal(t, MetricDelta{From: 120, To: 150, Change: 30}, trend.Account["totalFollowers"])
+	assert.NotContains(t, trend.Account, "coverClickRate", "windowed metrics are not diffed")
+	assert.Equal(t, 8.4, trend.Window["coverClickRate"])
+	assert.False(t, trend.PeriodChanged)
+
+	require.Len(t, trend.Notes, 3)
+	assert.Equal(t, "晒太阳", trend.Notes[0].Title, "notes sorted by view growth")
+	assert.Equal(t, MetricDelta{From: 10, To: 60, Change: 50}, trend.Notes[0].Metrics["views"])
+	assert.False(t, trend.Notes[0].New, "notes without id are matched by title")
+	assert.Equal(t, "n1", trend.Notes[1].NoteID)
+	assert.Equal(t, 10.0, trend.Notes[1].Metrics["views"].Change)
+	assert.True(t, trend.Notes[2].New)
+	assert.Equal(t, MetricDelta{From: 0, To: 5, Change: 5}, trend.Notes[2].Metrics["views"])
+	assert.NotContains(t, trend.Notes[2].Metrics, "coverClickRate", "note rates are not diffed")
+	assert.Contains(t, trend.Notes[2].Rates, "coverClickRate")
+}
+
+func TestComputeTrendPeriodChanged(t *testing.T) {
+	snapshots := []xiaohongshu.CreatorStats{snapshotAt(1, 100), snapshotAt(5, 150)}
+	snapshots[0].Account.Views = 9000
+	snapshots[1].Period = "近30日"
+	snapshots[1].Account.Views = 3000
+
+	trend, err := ComputeTrend(snapshots, time.Time{})
+	require.NoError(t, err)
+	assert.True(t, trend.PeriodChanged)
+	assert.Equal(t, "近7日", trend.BasePeriod)
+	assert.Equal(t, "近30日", trend.Period)
+	assert.Equal(t, 3000.0, trend.Window["views"])
+	assert.Equal(t, MetricDelta{From: 100, To: 150, Change: 50}, trend.Account["totalFollowers"], "cumulative metrics still diff")
+}
+
+func TestComputeTrendBaseline(t *testing.T) {
+	snapshots := []xiaohongshu.CreatorStats{snapshotAt(3, 120), snapshotAt(5, 150)}
+
+	trend, err := ComputeTrend(snapshots, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, snapshots[0].CollectedAt, trend.From, "falls back to the oldest snapshot")
+
+	trend, err = ComputeTrend(snapshots[1:], time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, trend.Account["totalFollowers"].Change)
+
+	_, err = ComputeTrend(nil, time.Time{})
+	assert.ErrorIs(t, err, xhserrors.ErrNoCreatorStats)
+}
+
+func TestStoreAppendLoad(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "stats", "creator_stats.jsonl"))
+
+	snapshots, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	require.NoError(t, store.Append(snapshotAt(5, 150)))
+	require.NoError(t, store.Append(snapshotAt(3, 120)))
+
+	snapshots, err = store.Load()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, int64(120), snapshots[0].Account.TotalFollowers, "loaded in collection order")
+	assert.Equal(t, int64(150), snapshots[1].Account.TotalFollowers)
+}
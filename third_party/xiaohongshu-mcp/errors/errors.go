@@ -4,3 +4,30 @@ import "errors"
 
 var ErrNoFeeds = errors.New("没有捕获到 feeds 数据")
 var ErrNoFeedDetail = errors.New("没有捕获到 feed 详情数据")
+
+// ErrCommentNotPosted 点击发送后评论未出现在评论区，通常是被风控拦截或发送失败
+var ErrCommentNotPosted = errors.New("评论提交后未出现在评论区，可能发送失败或被拦截")
+
+// ErrNotOwnComment 只能删除当前登录账号发表的评论
+var ErrNotOwnComment = errors.New("只能删除当前登录账号发表的评论")
+
+// ErrMessagesDisabled 私信功能默认关闭，需启动时显式开启
+var ErrMessagesDisabled = errors.New("私信功能未开启，需使用 -enable-messages 启动")
+
+// ErrMessageRecipientNotAllowed 私信对象不在允许名单中
+var ErrMessageRecipientNotAllowed = errors.New("该用户不在私信允许名单中")
+
+// ErrMessageNotSent 点击发送后私信未出现在会话中
+var ErrMessageNotSent = errors.New("私信发送后未出现在会话中，可能发送失败或被拦截")
+
+// ErrNoCreatorStats 还没有采集过创作中心数据，无法计算趋势
+var ErrNoCreatorStats = errors.New("还没有创作中心数据快照，请先采集一次")
+
+// ErrScheduledJobNotFound 定时发布任务不存在
+var ErrScheduledJobNotFound = errors.New("定时发布任务不存在")
+
+// ErrScheduledJobNotPending 只能取消或修改还在等待执行的定时发布任务
+var ErrScheduledJobNotPending = errors.New("定时发布任务已执行或已取消")
+
+// ErrCursorTooDeep 游标位置太深，单次分页在滚动上限内加载不到
+var ErrCursorTooDeep = errors.New("游标位置超出单次分页能滚动加载的范围")
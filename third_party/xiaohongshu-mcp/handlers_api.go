@@ -1,9 +1,13 @@
 package main
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
+	xhserrors "github.com/xpzouying/xiaohongshu-mcp/errors"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 
 	"github.com/gin-gonic/gin"
@@ -97,6 +101,10 @@ func (s *AppServer) publishHandler(c *gin.Context) {
 		return
 	}
 
+	if req.Draft {
+		respondSuccess(c, result, "已存入草稿箱")
+		return
+	}
 	respondSuccess(c, result, "发布成功")
 }
 
@@ -117,6 +125,10 @@ func (s *AppServer) publishVideoHandler(c *gin.Context) {
 		return
 	}
 
+	if req.Draft {
+		respondSuccess(c, result, "视频已存入草稿箱")
+		return
+	}
 	respondSuccess(c, result, "视频发布成功")
 }
 
@@ -130,6 +142,15 @@ func (s *AppServer) listFeedsHandler(c *gin.Context) {
 		return
 	}
 
+	if attach, _ := strconv.ParseBool(c.Query("attach_images")); attach {
+		maxImages, _ := strconv.Atoi(c.Query("max_images"))
+		maxDimension, _ := strconv.Atoi(c.Query("max_image_dimension"))
+		s.xiaohongshuService.AttachFeedCovers(result, ImageAttachOptions{
+			MaxImages:    maxImages,
+			MaxDimension: maxDimension,
+		})
+	}
+
 	c.Set("account", "ai-report")
 	respondSuccess(c, result, "获取Feeds列表成功")
 }
@@ -172,6 +193,111 @@ func (s *AppServer) searchFeedsHandler(c *gin.Context) {
 	respondSuccess(c, result, "搜索Feeds成功")
 }
 
+// searchSuggestHandler 获取搜索联想词
+func (s *AppServer) searchSuggestHandler(c *gin.Context) {
+	keyword := c.Query("keyword")
+	if keyword == "" {
+		respondError(c, http.StatusBadRequest, "MISSING_KEYWORD",
+			"缺少关键词参数", "keyword parameter is required")
+		return
+	}
+
+	result, err := s.xiaohongshuService.SearchSuggest(c.Request.Context(), keyword)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "SEARCH_SUGGEST_FAILED",
+			"获取搜索联想词失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取搜索联想词成功")
+}
+
+// trendingKeywordsHandler 获取热搜榜
+func (s *AppServer) trendingKeywordsHandler(c *gin.Context) {
+	result, err := s.xiaohongshuService.TrendingKeywords(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "TRENDING_KEYWORDS_FAILED",
+			"获取热搜榜失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取热搜榜成功")
+}
+
+// listEmojisHandler 获取评论表情列表
+func (s *AppServer) listEmojisHandler(c *gin.Context) {
+	result, err := s.xiaohongshuService.ListEmojis(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_EMOJIS_FAILED",
+			"获取评论表情失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取评论表情成功")
+}
+
+// topicFeedsHandler 获取话题页笔记
+func (s *AppServer) topicFeedsHandler(c *gin.Context) {
+	var req TopicFeedsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.TopicFeeds(c.Request.Context(), req.TopicID, req.Limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "TOPIC_FEEDS_FAILED",
+			"获取话题笔记失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取话题笔记成功")
+}
+
+// listCommentsHandler 分页读取评论
+func (s *AppServer) listCommentsHandler(c *gin.Context) {
+	var req ListCommentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ListComments(c.Request.Context(), req.FeedID, req.XsecToken, req.Cursor, req.Limit)
+	if err != nil {
+		respondPageError(c, "LIST_COMMENTS_FAILED", "读取评论失败", err)
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "读取评论成功")
+}
+
+// commentThreadHandler 读取评论回复
+func (s *AppServer) commentThreadHandler(c *gin.Context) {
+	var req CommentThreadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.CommentThread(c.Request.Context(), req.FeedID, req.XsecToken, req.CommentID, req.Limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "COMMENT_THREAD_FAILED",
+			"读取评论回复失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "读取评论回复成功")
+}
+
 // getFeedDetailHandler 获取Feed详情
 func (s *AppServer) getFeedDetailHandler(c *gin.Context) {
 	var req FeedDetailRequest
@@ -204,6 +330,23 @@ func (s *AppServer) getFeedDetailHandler(c *gin.Context) {
 		return
 	}
 
+	if req.DownloadMedia {
+		if err := s.xiaohongshuService.DownloadFeedMedia(result); err != nil {
+			logrus.Warnf("下载笔记媒体失败: %v", err)
+		}
+	}
+
+	if req.AttachImages {
+		s.xiaohongshuService.AttachFeedDetailImages(result, ImageAttachOptions{
+			MaxImages:    req.MaxImages,
+			MaxDimension: req.MaxImageDimension,
+		})
+	}
+
+	if req.CommentDigest || req.DigestOnly {
+		s.xiaohongshuService.DigestFeedComments(result, req.DigestOnly)
+	}
+
 	c.Set("account", "ai-report")
 	respondSuccess(c, result, "获取Feed详情成功")
 }
@@ -239,7 +382,12 @@ func (s *AppServer) postCommentHandler(c *gin.Context) {
 	}
 
 	// 发表评论
-	result, err := s.xiaohongshuService.PostCommentToFeed(c.Request.Context(), req.FeedID, req.XsecToken, req.Content)
+	result, err := s.xiaohongshuService.PostCommentToFeed(c.Request.Context(), &req)
+	if errors.Is(err, xhserrors.ErrCommentNotPosted) {
+		respondError(c, http.StatusBadGateway, "COMMENT_NOT_POSTED",
+			"评论未出现在评论区", err.Error())
+		return
+	}
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "POST_COMMENT_FAILED",
 			"发表评论失败", err.Error())
@@ -259,7 +407,12 @@ func (s *AppServer) replyCommentHandler(c *gin.Context) {
 		return
 	}
 
-	result, err := s.xiaohongshuService.ReplyCommentToFeed(c.Request.Context(), req.FeedID, req.XsecToken, req.CommentID, req.UserID, req.Content)
+	result, err := s.xiaohongshuService.ReplyCommentToFeed(c.Request.Context(), &req)
+	if errors.Is(err, xhserrors.ErrCommentNotPosted) {
+		respondError(c, http.StatusBadGateway, "COMMENT_NOT_POSTED",
+			"回复未出现在评论区", err.Error())
+		return
+	}
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "REPLY_COMMENT_FAILED",
 			"回复评论失败", err.Error())
@@ -270,6 +423,415 @@ func (s *AppServer) replyCommentHandler(c *gin.Context) {
 	respondSuccess(c, result, result.Message)
 }
 
+// deleteCommentHandler 删除自己发表的评论
+func (s *AppServer) deleteCommentHandler(c *gin.Context) {
+	var req DeleteCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.DeleteComment(c.Request.Context(), req.FeedID, req.XsecToken, req.CommentID)
+	if errors.Is(err, xhserrors.ErrNotOwnComment) {
+		respondError(c, http.StatusForbidden, "NOT_OWN_COMMENT",
+			"只能删除自己的评论", err.Error())
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "DELETE_COMMENT_FAILED",
+			"删除评论失败", err.Error())
+		return
+	}
+
+	logrus.Infof("删除评论 - Feed ID: %s, Comment ID: %s", req.FeedID, req.CommentID)
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// likeCommentHandler 点赞或取消点赞评论
+func (s *AppServer) likeCommentHandler(c *gin.Context) {
+	var req LikeCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.LikeComment(c.Request.Context(), req.FeedID, req.XsecToken, req.CommentID, req.Unlike)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIKE_COMMENT_FAILED",
+			"评论点赞操作失败", err.Error())
+		return
+	}
+
+	logrus.Infof("评论点赞 - Feed ID: %s, Comment ID: %s, Unlike: %v", req.FeedID, req.CommentID, req.Unlike)
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// followUserHandler 关注或取消关注用户
+func (s *AppServer) followUserHandler(c *gin.Context) {
+	var req FollowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.FollowUser(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FOLLOW_USER_FAILED",
+			"关注操作失败", err.Error())
+		return
+	}
+
+	logrus.Infof("关注用户 - User ID: %s, Feed ID: %s, Unfollow: %v, Changed: %v", req.UserID, req.FeedID, req.Unfollow, result.Changed)
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// myFollowersHandler 分页读取我的粉丝
+func (s *AppServer) myFollowersHandler(c *gin.Context) {
+	s.listFollowUsers(c, xiaohongshu.FollowListFollowers)
+}
+
+// myFollowingsHandler 分页读取我的关注
+func (s *AppServer) myFollowingsHandler(c *gin.Context) {
+	s.listFollowUsers(c, xiaohongshu.FollowListFollowings)
+}
+
+func (s *AppServer) listFollowUsers(c *gin.Context, kind xiaohongshu.FollowListKind) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	result, err := s.xiaohongshuService.ListFollowUsers(c.Request.Context(), kind, c.Query("cursor"), limit)
+	if err != nil {
+		respondPageError(c, "LIST_FOLLOW_USERS_FAILED", "读取粉丝/关注列表失败", err)
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "读取粉丝/关注列表成功")
+}
+
+// listDraftsHandler 草稿箱列表
+func (s *AppServer) listDraftsHandler(c *gin.Context) {
+	result, err := s.xiaohongshuService.ListDrafts(c.Request.Context(), c.Query("kind"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_DRAFTS_FAILED",
+			"获取草稿箱失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取草稿箱成功")
+}
+
+// publishDraftHandler 发布草稿
+func (s *AppServer) publishDraftHandler(c *gin.Context) {
+	var req DraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.PublishDraft(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "PUBLISH_DRAFT_FAILED",
+			"发布草稿失败", err.Error())
+		return
+	}
+
+	logrus.Infof("发布草稿 - Kind: %s, Index: %d, Title: %s", req.Kind, *req.Index, req.Title)
+	respondSuccess(c, result, result.Message)
+}
+
+// deleteDraftHandler 删除草稿
+func (s *AppServer) deleteDraftHandler(c *gin.Context) {
+	var req DraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.DeleteDraft(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "DELETE_DRAFT_FAILED",
+			"删除草稿失败", err.Error())
+		return
+	}
+
+	logrus.Infof("删除草稿 - Kind: %s, Index: %d, Title: %s", req.Kind, *req.Index, req.Title)
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// myNotesHandler 我的笔记列表
+func (s *AppServer) myNotesHandler(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	result, err := s.xiaohongshuService.ListMyNotes(c.Request.Context(), limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_MY_NOTES_FAILED",
+			"获取我的笔记失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取我的笔记成功")
+}
+
+// deleteNoteHandler 删除自己的笔记
+func (s *AppServer) deleteNoteHandler(c *gin.Context) {
+	var req DeleteNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.DeleteNote(c.Request.Context(), req.NoteID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "DELETE_NOTE_FAILED",
+			"删除笔记失败", err.Error())
+		return
+	}
+
+	logrus.Infof("删除笔记 - Note ID: %s", req.NoteID)
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// noteVisibilityHandler 修改笔记可见范围
+func (s *AppServer) noteVisibilityHandler(c *gin.Context) {
+	var req NoteVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.SetNoteVisibility(c.Request.Context(), req.NoteID, req.Private)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "NOTE_VISIBILITY_FAILED",
+			"修改笔记可见范围失败", err.Error())
+		return
+	}
+
+	logrus.Infof("修改笔记可见范围 - Note ID: %s, Private: %v, Changed: %v", req.NoteID, req.Private, result.Changed)
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// collectCreatorStatsHandler 采集创作中心数据
+func (s *AppServer) collectCreatorStatsHandler(c *gin.Context) {
+	result, err := s.xiaohongshuService.CollectCreatorStats(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "COLLECT_CREATOR_STATS_FAILED",
+			"采集创作中心数据失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "采集创作中心数据成功")
+}
+
+// creatorStatsTrendHandler 查询创作中心数据变化
+func (s *AppServer) creatorStatsTrendHandler(c *gin.Context) {
+	result, err := s.xiaohongshuService.CreatorStatsTrend(c.Query("since"))
+	if errors.Is(err, xhserrors.ErrNoCreatorStats) {
+		respondError(c, http.StatusNotFound, "NO_CREATOR_STATS",
+			"还没有创作中心数据", err.Error())
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "CREATOR_STATS_TREND_FAILED",
+			"查询创作中心数据变化失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "查询创作中心数据变化成功")
+}
+
+// listScheduledPublishesHandler 本地定时发布任务列表
+func (s *AppServer) listScheduledPublishesHandler(c *gin.Context) {
+	result, err := s.xiaohongshuService.ListScheduledPublishes()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_SCHEDULED_FAILED",
+			"获取定时发布任务失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取定时发布任务成功")
+}
+
+// cancelScheduledPublishHandler 取消本地定时发布任务
+func (s *AppServer) cancelScheduledPublishHandler(c *gin.Context) {
+	var req ScheduledPublishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.CancelScheduledPublish(req.JobID)
+	if err != nil {
+		respondScheduleError(c, "CANCEL_SCHEDULED_FAILED", "取消定时发布任务失败", err)
+		return
+	}
+
+	logrus.Infof("取消定时发布任务 - JobID: %s", req.JobID)
+	respondSuccess(c, result, "定时发布任务已取消")
+}
+
+// rescheduleHandler 修改本地定时发布时间
+func (s *AppServer) rescheduleHandler(c *gin.Context) {
+	var req RescheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.RescheduleScheduledPublish(req.JobID, req.ScheduleAt)
+	if err != nil {
+		respondScheduleError(c, "RESCHEDULE_FAILED", "修改定时发布时间失败", err)
+		return
+	}
+
+	logrus.Infof("修改定时发布时间 - JobID: %s, ScheduleAt: %s", req.JobID, req.ScheduleAt)
+	respondSuccess(c, result, "定时发布时间已修改")
+}
+
+// respondScheduleError 定时发布任务不存在或已不在等待中时返回对应的错误码
+func respondScheduleError(c *gin.Context, code, message string, err error) {
+	switch {
+	case errors.Is(err, xhserrors.ErrScheduledJobNotFound):
+		respondError(c, http.StatusNotFound, "SCHEDULED_JOB_NOT_FOUND", "定时发布任务不存在", err.Error())
+	case errors.Is(err, xhserrors.ErrScheduledJobNotPending):
+		respondError(c, http.StatusConflict, "SCHEDULED_JOB_NOT_PENDING", "定时发布任务已执行或已取消", err.Error())
+	default:
+		respondError(c, http.StatusInternalServerError, code, message, err.Error())
+	}
+}
+
+// respondPageError 分页读取失败时，游标太深单独返回 CURSOR_TOO_DEEP
+func respondPageError(c *gin.Context, code, message string, err error) {
+	if errors.Is(err, xhserrors.ErrCursorTooDeep) {
+		respondError(c, http.StatusUnprocessableEntity, "CURSOR_TOO_DEEP", "游标位置太深，无法继续翻页", err.Error())
+		return
+	}
+	respondError(c, http.StatusInternalServerError, code, message, err.Error())
+}
+
+// notificationsHandler 读取通知
+func (s *AppServer) notificationsHandler(c *gin.Context) {
+	req := NotificationsRequest{Since: c.Query("since")}
+	if tabs := c.Query("tabs"); tabs != "" {
+		req.Tabs = strings.Split(tabs, ",")
+	}
+	req.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	result, err := s.xiaohongshuService.Notifications(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "GET_NOTIFICATIONS_FAILED",
+			"读取通知失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "读取通知成功")
+}
+
+// listConversationsHandler 私信会话列表
+func (s *AppServer) listConversationsHandler(c *gin.Context) {
+	result, err := s.xiaohongshuService.ListConversations(c.Request.Context())
+	if errors.Is(err, xhserrors.ErrMessagesDisabled) {
+		respondError(c, http.StatusForbidden, "MESSAGES_DISABLED",
+			"私信功能未开启", err.Error())
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_CONVERSATIONS_FAILED",
+			"获取私信会话失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取私信会话成功")
+}
+
+// readConversationHandler 读取与某个用户的私信
+func (s *AppServer) readConversationHandler(c *gin.Context) {
+	var req ReadConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ReadConversation(c.Request.Context(), req.UserID, req.Limit)
+	if respondMessageAccessError(c, err) {
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "READ_CONVERSATION_FAILED",
+			"读取私信失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "读取私信成功")
+}
+
+// sendMessageHandler 发送私信
+func (s *AppServer) sendMessageHandler(c *gin.Context) {
+	var req SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.SendMessage(c.Request.Context(), req.UserID, req.Content)
+	if respondMessageAccessError(c, err) {
+		return
+	}
+	if errors.Is(err, xhserrors.ErrMessageNotSent) {
+		respondError(c, http.StatusBadGateway, "MESSAGE_NOT_SENT",
+			"私信未发送成功", err.Error())
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "SEND_MESSAGE_FAILED",
+			"发送私信失败", err.Error())
+		return
+	}
+
+	logrus.Infof("发送私信 - User ID: %s", req.UserID)
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// respondMessageAccessError 私信未开启或对象不在允许名单时返回 403，已处理时返回 true
+func respondMessageAccessError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, xhserrors.ErrMessagesDisabled):
+		respondError(c, http.StatusForbidden, "MESSAGES_DISABLED",
+			"私信功能未开启", err.Error())
+	case errors.Is(err, xhserrors.ErrMessageRecipientNotAllowed):
+		respondError(c, http.StatusForbidden, "MESSAGE_RECIPIENT_NOT_ALLOWED",
+			"该用户不在私信允许名单中", err.Error())
+	default:
+		return false
+	}
+	return true
+}
+
 // healthHandler 健康检查
 func healthHandler(c *gin.Context) {
 	respondSuccess(c, map[string]any{
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/xpzouying/xiaohongshu-mcp/configs"
@@ -13,10 +15,15 @@ func main() {
 		headless bool
 		binPath  string // 浏览器二进制文件路径
 		port     string
+
+		enableMessages bool
+		messageAllow   string // 允许收发私信的用户ID，逗号分隔
 	)
 	flag.BoolVar(&headless, "headless", true, "是否无头模式")
 	flag.StringVar(&binPath, "bin", "", "浏览器二进制文件路径")
 	flag.StringVar(&port, "port", ":18060", "端口")
+	flag.BoolVar(&enableMessages, "enable-messages", false, "是否开启私信功能")
+	flag.StringVar(&messageAllow, "message-allow", "", "只允许与这些用户收发私信，逗号分隔的用户ID；为空不限制")
 	flag.Parse()
 
 	if len(binPath) == 0 {
@@ -25,9 +32,13 @@ func main() {
 
 	configs.InitHeadless(headless)
 	configs.SetBinPath(binPath)
+	configs.InitMessages(enableMessages, strings.Split(messageAllow, ","))
 
 	// 初始化服务
 	xiaohongshuService := NewXiaohongshuService()
+	if err := xiaohongshuService.StartScheduler(context.Background()); err != nil {
+		logrus.Fatalf("failed to start publish scheduler: %v", err)
+	}
 
 	// 创建并启动应用服务器
 	appServer := NewAppServer(xiaohongshuService)
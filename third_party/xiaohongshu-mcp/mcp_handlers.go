@@ -132,18 +132,25 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 		}
 	}
 
+	mentions := convertInterfacesToStrings(args["mentions"])
+
 	// 解析定时发布参数
 	scheduleAt, _ := args["schedule_at"].(string)
+	scheduleMode, _ := args["schedule_mode"].(string)
+	draft, _ := args["draft"].(bool)
 
-	logrus.Infof("MCP: 发布内容 - 标题: %s, 图片数量: %d, 标签数量: %d, 定时: %s", title, len(imagePaths), len(tags), scheduleAt)
+	logrus.Infof("MCP: 发布内容 - 标题: %s, 图片数量: %d, 标签数量: %d, 提及数量: %d, 定时: %s, 草稿: %v", title, len(imagePaths), len(tags), len(mentions), scheduleAt, draft)
 
 	// 构建发布请求
 	req := &PublishRequest{
-		Title:      title,
-		Content:    content,
-		Images:     imagePaths,
-		Tags:       tags,
-		ScheduleAt: scheduleAt,
+		Title:        title,
+		Content:      content,
+		Images:       imagePaths,
+		Tags:         tags,
+		Mentions:     mentions,
+		ScheduleAt:   scheduleAt,
+		ScheduleMode: scheduleMode,
+		Draft:        draft,
 	}
 
 	// 执行发布
@@ -159,6 +166,11 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 	}
 
 	resultText := fmt.Sprintf("内容发布成功: %+v", result)
+	if draft {
+		resultText = fmt.Sprintf("内容已存入草稿箱: %+v", result)
+	} else if result.JobID != "" {
+		resultText = fmt.Sprintf("内容已加入本地定时发布: %+v", result)
+	}
 	return &MCPToolResult{
 		Content: []MCPContent{{
 			Type: "text",
@@ -193,18 +205,25 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 		}
 	}
 
+	mentions := convertInterfacesToStrings(args["mentions"])
+
 	// 解析定时发布参数
 	scheduleAt, _ := args["schedule_at"].(string)
+	scheduleMode, _ := args["schedule_mode"].(string)
+	draft, _ := args["draft"].(bool)
 
-	logrus.Infof("MCP: 发布视频 - 标题: %s, 标签数量: %d, 定时: %s", title, len(tags), scheduleAt)
+	logrus.Infof("MCP: 发布视频 - 标题: %s, 标签数量: %d, 提及数量: %d, 定时: %s, 草稿: %v", title, len(tags), len(mentions), scheduleAt, draft)
 
 	// 构建发布请求
 	req := &PublishVideoRequest{
-		Title:      title,
-		Content:    content,
-		Video:      videoPath,
-		Tags:       tags,
-		ScheduleAt: scheduleAt,
+		Title:        title,
+		Content:      content,
+		Video:        videoPath,
+		Tags:         tags,
+		Mentions:     mentions,
+		ScheduleAt:   scheduleAt,
+		ScheduleMode: scheduleMode,
+		Draft:        draft,
 	}
 
 	// 执行发布
@@ -220,6 +239,11 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 	}
 
 	resultText := fmt.Sprintf("视频发布成功: %+v", result)
+	if draft {
+		resultText = fmt.Sprintf("视频已存入草稿箱: %+v", result)
+	} else if result.JobID != "" {
+		resultText = fmt.Sprintf("视频已加入本地定时发布: %+v", result)
+	}
 	return &MCPToolResult{
 		Content: []MCPContent{{
 			Type: "text",
@@ -229,7 +253,7 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 }
 
 // handleListFeeds 处理获取Feeds列表
-func (s *AppServer) handleListFeeds(ctx context.Context) *MCPToolResult {
+func (s *AppServer) handleListFeeds(ctx context.Context, args ListFeedsArgs) *MCPToolResult {
 	logrus.Info("MCP: 获取Feeds列表")
 
 	result, err := s.xiaohongshuService.ListFeeds(ctx)
@@ -243,6 +267,17 @@ func (s *AppServer) handleListFeeds(ctx context.Context) *MCPToolResult {
 		}
 	}
 
+	if args.AttachImages {
+		s.xiaohongshuService.AttachFeedCovers(result, ImageAttachOptions{
+			MaxImages:    args.MaxImages,
+			MaxDimension: args.MaxImageDimension,
+		})
+	}
+
+	// 图片以图片内容单独返回，不放进 JSON 文本
+	images := result.Images
+	result.Images = nil
+
 	// 格式化输出，转换为JSON字符串
 	jsonData, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
@@ -255,12 +290,12 @@ func (s *AppServer) handleListFeeds(ctx context.Context) *MCPToolResult {
 		}
 	}
 
-	return &MCPToolResult{
+	return withImageContents(&MCPToolResult{
 		Content: []MCPContent{{
 			Type: "text",
 			Text: string(jsonData),
 		}},
-	}
+	}, images)
 }
 
 // handleSearchFeeds 处理搜索Feeds
@@ -319,6 +354,252 @@ func (s *AppServer) handleSearchFeeds(ctx context.Context, args SearchFeedsArgs)
 	}
 }
 
+// handleSearchSuggest 处理获取搜索联想词
+func (s *AppServer) handleSearchSuggest(ctx context.Context, args SearchSuggestArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取搜索联想词 - 前缀: %s", args.Keyword)
+
+	if args.Keyword == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取搜索联想词失败: 缺少keyword参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	result, err := s.xiaohongshuService.SearchSuggest(ctx, args.Keyword)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取搜索联想词失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("获取搜索联想词成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleTrendingKeywords 处理获取热搜榜
+func (s *AppServer) handleTrendingKeywords(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取热搜榜")
+
+	result, err := s.xiaohongshuService.TrendingKeywords(ctx)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取热搜榜失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("获取热搜榜成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleListEmojis 处理获取评论表情列表
+func (s *AppServer) handleListEmojis(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取评论表情")
+
+	result, err := s.xiaohongshuService.ListEmojis(ctx)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取评论表情失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("获取评论表情成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleListComments 处理分页读取评论
+func (s *AppServer) handleListComments(ctx context.Context, args ListCommentsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 分页读取评论 - Feed ID: %s, cursor=%s, limit=%d", args.FeedID, args.Cursor, args.Limit)
+
+	if args.FeedID == "" || args.XsecToken == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "读取评论失败: 缺少feed_id或xsec_token参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	result, err := s.xiaohongshuService.ListComments(ctx, args.FeedID, args.XsecToken, args.Cursor, args.Limit)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "读取评论失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("读取评论成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleCommentThread 处理读取评论回复
+func (s *AppServer) handleCommentThread(ctx context.Context, args CommentThreadArgs) *MCPToolResult {
+	logrus.Infof("MCP: 读取评论回复 - Feed ID: %s, Comment ID: %s, limit=%d", args.FeedID, args.CommentID, args.Limit)
+
+	if args.FeedID == "" || args.XsecToken == "" || args.CommentID == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "读取评论回复失败: 缺少feed_id、xsec_token或comment_id参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	result, err := s.xiaohongshuService.CommentThread(ctx, args.FeedID, args.XsecToken, args.CommentID, args.Limit)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "读取评论回复失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("读取评论回复成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleTopicFeeds 处理获取话题页笔记
+func (s *AppServer) handleTopicFeeds(ctx context.Context, args TopicFeedsArgs) *MCPToolResult {
+	logrus.Info("MCP: 获取话题页笔记")
+
+	if args.TopicID == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取话题笔记失败: 缺少topic_id参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	logrus.Infof("MCP: 获取话题页笔记 - Topic ID: %s, limit=%d", args.TopicID, args.Limit)
+
+	result, err := s.xiaohongshuService.TopicFeeds(ctx, args.TopicID, args.Limit)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取话题笔记失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("获取话题笔记成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
 // handleGetFeedDetail 处理获取Feed详情
 func (s *AppServer) handleGetFeedDetail(ctx context.Context, args map[string]any) *MCPToolResult {
 	logrus.Info("MCP: 获取Feed详情")
@@ -404,6 +685,18 @@ func (s *AppServer) handleGetFeedDetail(ctx context.Context, args map[string]any
 		config.ScrollSpeed = raw
 	}
 
+	downloadMedia, _ := args["download_media"].(bool)
+	attachImages, _ := args["attach_images"].(bool)
+	imageOpts := ImageAttachOptions{}
+	if v, ok := args["max_images"].(int); ok {
+		imageOpts.MaxImages = v
+	}
+	if v, ok := args["max_image_dimension"].(int); ok {
+		imageOpts.MaxDimension = v
+	}
+	commentDigest, _ := args["comment_digest"].(bool)
+	digestOnly, _ := args["digest_only"].(bool)
+
 	logrus.Infof("MCP: 获取Feed详情 - Feed ID: %s, loadAllComments=%v, config=%+v", feedID, loadAll, config)
 
 	result, err := s.xiaohongshuService.GetFeedDetailWithConfig(ctx, feedID, xsecToken, loadAll, config)
@@ -417,6 +710,24 @@ func (s *AppServer) handleGetFeedDetail(ctx context.Context, args map[string]any
 		}
 	}
 
+	if downloadMedia {
+		if err := s.xiaohongshuService.DownloadFeedMedia(result); err != nil {
+			logrus.Warnf("MCP: 下载笔记媒体失败: %v", err)
+		}
+	}
+
+	if attachImages {
+		s.xiaohongshuService.AttachFeedDetailImages(result, imageOpts)
+	}
+
+	if commentDigest || digestOnly {
+		s.xiaohongshuService.DigestFeedComments(result, digestOnly)
+	}
+
+	// 图片以图片内容单独返回，不放进 JSON 文本
+	images := result.Images
+	result.Images = nil
+
 	// 格式化输出，转换为JSON字符串
 	jsonData, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
@@ -429,12 +740,23 @@ func (s *AppServer) handleGetFeedDetail(ctx context.Context, args map[string]any
 		}
 	}
 
-	return &MCPToolResult{
+	return withImageContents(&MCPToolResult{
 		Content: []MCPContent{{
 			Type: "text",
 			Text: string(jsonData),
 		}},
+	}, images)
+}
+
+// withImageContents 在结果后追加图片内容，每张图片前附一行来源说明
+func withImageContents(result *MCPToolResult, images []ImageAttachment) *MCPToolResult {
+	for i, img := range images {
+		result.Content = append(result.Content,
+			MCPContent{Type: "text", Text: fmt.Sprintf("[图片 %d] feed_id=%s", i+1, img.FeedID)},
+			MCPContent{Type: "image", MimeType: img.MimeType, Data: img.Data},
+		)
 	}
+	return result
 }
 
 // handleUserProfile 获取用户主页
@@ -607,10 +929,19 @@ func (s *AppServer) handlePostComment(ctx context.Context, args map[string]inter
 		}
 	}
 
-	logrus.Infof("MCP: 发表评论 - Feed ID: %s, 内容长度: %d", feedID, len(content))
+	mentions := convertInterfacesToStrings(args["mentions"])
+	image, _ := args["image"].(string)
+
+	logrus.Infof("MCP: 发表评论 - Feed ID: %s, 内容长度: %d, 提及: %v, 图片: %s", feedID, len(content), mentions, image)
 
 	// 发表评论
-	result, err := s.xiaohongshuService.PostCommentToFeed(ctx, feedID, xsecToken, content)
+	result, err := s.xiaohongshuService.PostCommentToFeed(ctx, &PostCommentRequest{
+		FeedID:    feedID,
+		XsecToken: xsecToken,
+		Content:   content,
+		Mentions:  mentions,
+		Image:     image,
+	})
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -621,8 +952,7 @@ func (s *AppServer) handlePostComment(ctx context.Context, args map[string]inter
 		}
 	}
 
-	// 返回成功结果，只包含feed_id
-	resultText := fmt.Sprintf("评论发表成功 - Feed ID: %s", result.FeedID)
+	resultText := fmt.Sprintf("评论发表成功 - Feed ID: %s, Comment ID: %s, Create Time: %d%s", result.FeedID, result.CommentID, result.CreateTime, formatMentions(result.Mentions))
 	return &MCPToolResult{
 		Content: []MCPContent{{
 			Type: "text",
@@ -631,61 +961,605 @@ func (s *AppServer) handlePostComment(ctx context.Context, args map[string]inter
 	}
 }
 
-// handleReplyComment 处理回复评论
-func (s *AppServer) handleReplyComment(ctx context.Context, args map[string]interface{}) *MCPToolResult {
-	logrus.Info("MCP: 回复评论")
+// handleDeleteComment 处理删除自己的评论
+func (s *AppServer) handleDeleteComment(ctx context.Context, args DeleteCommentArgs) *MCPToolResult {
+	logrus.Infof("MCP: 删除评论 - Feed ID: %s, Comment ID: %s", args.FeedID, args.CommentID)
 
-	// 解析参数
-	feedID, ok := args["feed_id"].(string)
-	if !ok || feedID == "" {
+	if args.FeedID == "" || args.XsecToken == "" || args.CommentID == "" {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
-				Text: "回复评论失败: 缺少feed_id参数",
+				Text: "删除评论失败: 缺少feed_id、xsec_token或comment_id参数",
 			}},
 			IsError: true,
 		}
 	}
 
-	xsecToken, ok := args["xsec_token"].(string)
-	if !ok || xsecToken == "" {
+	result, err := s.xiaohongshuService.DeleteComment(ctx, args.FeedID, args.XsecToken, args.CommentID)
+	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
-				Text: "回复评论失败: 缺少xsec_token参数",
+				Text: "删除评论失败: " + err.Error(),
 			}},
 			IsError: true,
 		}
 	}
 
-	commentID, _ := args["comment_id"].(string)
-	userID, _ := args["user_id"].(string)
-	if commentID == "" && userID == "" {
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: fmt.Sprintf("评论删除成功 - Feed ID: %s, Comment ID: %s", result.FeedID, result.CommentID),
+		}},
+	}
+}
+
+// handleLikeComment 处理评论点赞/取消点赞
+func (s *AppServer) handleLikeComment(ctx context.Context, args LikeCommentArgs) *MCPToolResult {
+	logrus.Infof("MCP: 评论点赞 - Feed ID: %s, Comment ID: %s, Unlike: %v", args.FeedID, args.CommentID, args.Unlike)
+
+	if args.FeedID == "" || args.XsecToken == "" || args.CommentID == "" {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
-				Text: "回复评论失败: 缺少comment_id或user_id参数",
+				Text: "评论点赞操作失败: 缺少feed_id、xsec_token或comment_id参数",
 			}},
 			IsError: true,
 		}
 	}
 
-	content, ok := args["content"].(string)
-	if !ok || content == "" {
+	result, err := s.xiaohongshuService.LikeComment(ctx, args.FeedID, args.XsecToken, args.CommentID, args.Unlike)
+	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
-				Text: "回复评论失败: 缺少content参数",
+				Text: "评论点赞操作失败: " + err.Error(),
 			}},
 			IsError: true,
 		}
 	}
 
-	logrus.Infof("MCP: 回复评论 - Feed ID: %s, Comment ID: %s, User ID: %s, 内容长度: %d", feedID, commentID, userID, len(content))
-
-	// 回复评论
-	result, err := s.xiaohongshuService.ReplyCommentToFeed(ctx, feedID, xsecToken, commentID, userID, content)
-	if err != nil {
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: fmt.Sprintf("%s - Feed ID: %s, Comment ID: %s", result.Message, result.FeedID, result.CommentID),
+		}},
+	}
+}
+
+// handleFollowUser 处理关注/取消关注用户
+func (s *AppServer) handleFollowUser(ctx context.Context, args FollowUserArgs) *MCPToolResult {
+	logrus.Infof("MCP: 关注用户 - User ID: %s, Feed ID: %s, Unfollow: %v", args.UserID, args.FeedID, args.Unfollow)
+
+	if (args.UserID == "" && args.FeedID == "") || args.XsecToken == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "关注操作失败: 缺少user_id或feed_id，或缺少xsec_token参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	result, err := s.xiaohongshuService.FollowUser(ctx, &FollowRequest{
+		UserID:    args.UserID,
+		FeedID:    args.FeedID,
+		XsecToken: args.XsecToken,
+		Unfollow:  args.Unfollow,
+	})
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "关注操作失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("%s - changed: %v", result.Message, result.Changed),
+			}},
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleListFollowUsers 处理分页读取粉丝/关注
+func (s *AppServer) handleListFollowUsers(ctx context.Context, kind xiaohongshu.FollowListKind, args ListFollowUsersArgs) *MCPToolResult {
+	logrus.Infof("MCP: 读取%s - cursor=%s, limit=%d", kind, args.Cursor, args.Limit)
+
+	result, err := s.xiaohongshuService.ListFollowUsers(ctx, kind, args.Cursor, args.Limit)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "读取粉丝/关注列表失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("读取粉丝/关注列表成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleListScheduledPublishes 处理获取本地定时发布任务
+func (s *AppServer) handleListScheduledPublishes(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取本地定时发布任务")
+
+	result, err := s.xiaohongshuService.ListScheduledPublishes()
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取定时发布任务失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("获取定时发布任务成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleCancelScheduledPublish 处理取消本地定时发布任务
+func (s *AppServer) handleCancelScheduledPublish(ctx context.Context, args ScheduledPublishArgs) *MCPToolResult {
+	logrus.Infof("MCP: 取消定时发布任务 - job_id=%s", args.JobID)
+
+	if args.JobID == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "取消定时发布任务失败: 缺少job_id参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	job, err := s.xiaohongshuService.CancelScheduledPublish(args.JobID)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "取消定时发布任务失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: fmt.Sprintf("定时发布任务已取消 - %s: %s", job.ID, job.Title),
+		}},
+	}
+}
+
+// handleReschedulePublish 处理修改本地定时发布时间
+func (s *AppServer) handleReschedulePublish(ctx context.Context, args RescheduleArgs) *MCPToolResult {
+	logrus.Infof("MCP: 修改定时发布时间 - job_id=%s, schedule_at=%s", args.JobID, args.ScheduleAt)
+
+	if args.JobID == "" || args.ScheduleAt == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "修改定时发布时间失败: 缺少job_id或schedule_at参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	job, err := s.xiaohongshuService.RescheduleScheduledPublish(args.JobID, args.ScheduleAt)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "修改定时发布时间失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: fmt.Sprintf("定时发布时间已修改 - %s: %s，将于 %s 发布", job.ID, job.Title, job.RunAt.Format("2006-01-02 15:04")),
+		}},
+	}
+}
+
+// handleListDrafts 处理获取草稿箱
+func (s *AppServer) handleListDrafts(ctx context.Context, args ListDraftsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取草稿箱 - kind=%q", args.Kind)
+
+	result, err := s.xiaohongshuService.ListDrafts(ctx, args.Kind)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取草稿箱失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("获取草稿箱成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleCollectCreatorStats 处理采集创作中心数据
+func (s *AppServer) handleCollectCreatorStats(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 采集创作中心数据")
+
+	result, err := s.xiaohongshuService.CollectCreatorStats(ctx)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "采集创作中心数据失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("采集创作中心数据成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleCreatorStatsTrend 处理查询创作中心数据变化
+func (s *AppServer) handleCreatorStatsTrend(ctx context.Context, args CreatorStatsTrendArgs) *MCPToolResult {
+	logrus.Infof("MCP: 查询创作中心数据变化 - since=%q", args.Since)
+
+	result, err := s.xiaohongshuService.CreatorStatsTrend(args.Since)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "查询创作中心数据变化失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("查询创作中心数据变化成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleListMyNotes 处理获取我的笔记
+func (s *AppServer) handleListMyNotes(ctx context.Context, args ListMyNotesArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取我的笔记 - limit=%d", args.Limit)
+
+	result, err := s.xiaohongshuService.ListMyNotes(ctx, args.Limit)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取我的笔记失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("获取我的笔记成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleNotifications 处理读取通知
+func (s *AppServer) handleNotifications(ctx context.Context, args NotificationsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 读取通知 - tabs=%v, since=%s, limit=%d", args.Tabs, args.Since, args.Limit)
+
+	result, err := s.xiaohongshuService.Notifications(ctx, &NotificationsRequest{
+		Tabs:  args.Tabs,
+		Since: args.Since,
+		Limit: args.Limit,
+	})
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "读取通知失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("读取通知成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleListConversations 处理获取私信会话列表
+func (s *AppServer) handleListConversations(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 获取私信会话列表")
+
+	result, err := s.xiaohongshuService.ListConversations(ctx)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "获取私信会话失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("获取私信会话成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleReadConversation 处理读取私信会话
+func (s *AppServer) handleReadConversation(ctx context.Context, args ReadConversationArgs) *MCPToolResult {
+	logrus.Infof("MCP: 读取私信 - User ID: %s, limit=%d", args.UserID, args.Limit)
+
+	if args.UserID == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "读取私信失败: 缺少user_id参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	result, err := s.xiaohongshuService.ReadConversation(ctx, args.UserID, args.Limit)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "读取私信失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: fmt.Sprintf("读取私信成功，但序列化失败: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleSendMessage 处理发送私信
+func (s *AppServer) handleSendMessage(ctx context.Context, args SendMessageArgs) *MCPToolResult {
+	logrus.Infof("MCP: 发送私信 - User ID: %s", args.UserID)
+
+	if args.UserID == "" || args.Content == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "发送私信失败: 缺少user_id或content参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	result, err := s.xiaohongshuService.SendMessage(ctx, args.UserID, args.Content)
+	if err != nil {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "发送私信失败: " + err.Error(),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: fmt.Sprintf("%s - User ID: %s", result.Message, result.UserID),
+		}},
+	}
+}
+
+// handleReplyComment 处理回复评论
+func (s *AppServer) handleReplyComment(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+	logrus.Info("MCP: 回复评论")
+
+	// 解析参数
+	feedID, ok := args["feed_id"].(string)
+	if !ok || feedID == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "回复评论失败: 缺少feed_id参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	xsecToken, ok := args["xsec_token"].(string)
+	if !ok || xsecToken == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "回复评论失败: 缺少xsec_token参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	commentID, _ := args["comment_id"].(string)
+	userID, _ := args["user_id"].(string)
+	if commentID == "" && userID == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "回复评论失败: 缺少comment_id或user_id参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	content, ok := args["content"].(string)
+	if !ok || content == "" {
+		return &MCPToolResult{
+			Content: []MCPContent{{
+				Type: "text",
+				Text: "回复评论失败: 缺少content参数",
+			}},
+			IsError: true,
+		}
+	}
+
+	mentions := convertInterfacesToStrings(args["mentions"])
+	image, _ := args["image"].(string)
+
+	logrus.Infof("MCP: 回复评论 - Feed ID: %s, Comment ID: %s, User ID: %s, 内容长度: %d, 提及: %v, 图片: %s", feedID, commentID, userID, len(content), mentions, image)
+
+	// 回复评论
+	result, err := s.xiaohongshuService.ReplyCommentToFeed(ctx, &ReplyCommentRequest{
+		FeedID:    feedID,
+		XsecToken: xsecToken,
+		CommentID: commentID,
+		UserID:    userID,
+		Content:   content,
+		Mentions:  mentions,
+		Image:     image,
+	})
+	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
 				Type: "text",
@@ -696,7 +1570,8 @@ func (s *AppServer) handleReplyComment(ctx context.Context, args map[string]inte
 	}
 
 	// 返回成功结果
-	responseText := fmt.Sprintf("评论回复成功 - Feed ID: %s, Comment ID: %s, User ID: %s", result.FeedID, result.TargetCommentID, result.TargetUserID)
+	responseText := fmt.Sprintf("评论回复成功 - Feed ID: %s, Comment ID: %s, User ID: %s, Reply ID: %s, Create Time: %d%s",
+		result.FeedID, result.TargetCommentID, result.TargetUserID, result.CommentID, result.CreateTime, formatMentions(result.Mentions))
 	return &MCPToolResult{
 		Content: []MCPContent{{
 			Type: "text",
@@ -704,3 +1579,15 @@ func (s *AppServer) handleReplyComment(ctx context.Context, args map[string]inte
 		}},
 	}
 }
+
+// formatMentions 把已插入的提及格式化为结果文本后缀，没有提及时返回空串
+func formatMentions(mentions []xiaohongshu.MentionUser) string {
+	if len(mentions) == 0 {
+		return ""
+	}
+	names := make([]string, len(mentions))
+	for i, m := range mentions {
+		names[i] = fmt.Sprintf("@%s(%s)", m.Nickname, m.UserID)
+	}
+	return ", Mentions: " + strings.Join(names, " ")
+}
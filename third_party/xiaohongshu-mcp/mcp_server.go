@@ -8,6 +8,7 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
 // Helper functions for annotation pointers
@@ -17,20 +18,33 @@ func boolPtr(b bool) *bool { return &b }
 
 // PublishContentArgs 发布内容的参数
 type PublishContentArgs struct {
-	Title      string   `json:"title" jsonschema:"内容标题（小红书限制：最多20个中文字或英文单词）"`
-	Content    string   `json:"content" jsonschema:"正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可"`
-	Images     []string `json:"images" jsonschema:"图片路径列表（至少需要1张图片）。支持两种方式：1. HTTP/HTTPS图片链接（自动下载）；2. 本地图片绝对路径（推荐，如:/Users/user/image.jpg）"`
-	Tags       []string `json:"tags,omitempty" jsonschema:"话题标签列表（可选参数），如 [美食, 旅行, 生活]"`
-	ScheduleAt string   `json:"schedule_at,omitempty" jsonschema:"定时发布时间（可选），ISO8601格式如 2024-01-20T10:30:00+08:00，支持1小时至14天内。不填则立即发布"`
+	Title        string   `json:"title" jsonschema:"内容标题（小红书限制：最多20个中文字或英文单词）"`
+	Content      string   `json:"content" jsonschema:"正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可"`
+	Images       []string `json:"images" jsonschema:"图片路径列表（至少需要1张图片）。支持两种方式：1. HTTP/HTTPS图片链接（自动下载）；2. 本地图片绝对路径（推荐，如:/Users/user/image.jpg）"`
+	Tags         []string `json:"tags,omitempty" jsonschema:"话题标签列表（可选参数），如 [美食, 旅行, 生活]"`
+	Mentions     []string `json:"mentions,omitempty" jsonschema:"要@的用户ID或昵称列表（可选），会通过@选择弹窗插入真正的提及，找不到用户时发布失败"`
+	ScheduleAt   string   `json:"schedule_at,omitempty" jsonschema:"定时发布时间（可选），ISO8601格式如 2024-01-20T10:30:00+08:00，可以是任意未来时间。不填则立即发布"`
+	ScheduleMode string   `json:"schedule_mode,omitempty" jsonschema:"定时发布方式（可选）：auto（默认，1小时至14天内用平台定时，其余用本地定时）、platform、local"`
+	Draft        bool     `json:"draft,omitempty" jsonschema:"是否只存入草稿箱不发布（可选），用于发布前让人审核，不能与schedule_at同时使用"`
 }
 
 // PublishVideoArgs 发布视频的参数（仅支持本地单个视频文件）
 type PublishVideoArgs struct {
-	Title      string   `json:"title" jsonschema:"内容标题（小红书限制：最多20个中文字或英文单词）"`
-	Content    string   `json:"content" jsonschema:"正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可"`
-	Video      string   `json:"video" jsonschema:"本地视频绝对路径（仅支持单个视频文件，如:/Users/user/video.mp4）"`
-	Tags       []string `json:"tags,omitempty" jsonschema:"话题标签列表（可选参数），如 [美食, 旅行, 生活]"`
-	ScheduleAt string   `json:"schedule_at,omitempty" jsonschema:"定时发布时间（可选），ISO8601格式如 2024-01-20T10:30:00+08:00，支持1小时至14天内。不填则立即发布"`
+	Title        string   `json:"title" jsonschema:"内容标题（小红书限制：最多20个中文字或英文单词）"`
+	Content      string   `json:"content" jsonschema:"正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可"`
+	Video        string   `json:"video" jsonschema:"本地视频绝对路径（仅支持单个视频文件，如:/Users/user/video.mp4）"`
+	Tags         []string `json:"tags,omitempty" jsonschema:"话题标签列表（可选参数），如 [美食, 旅行, 生活]"`
+	Mentions     []string `json:"mentions,omitempty" jsonschema:"要@的用户ID或昵称列表（可选），会通过@选择弹窗插入真正的提及，找不到用户时发布失败"`
+	ScheduleAt   string   `json:"schedule_at,omitempty" jsonschema:"定时发布时间（可选），ISO8601格式如 2024-01-20T10:30:00+08:00，可以是任意未来时间。不填则立即发布"`
+	ScheduleMode string   `json:"schedule_mode,omitempty" jsonschema:"定时发布方式（可选）：auto（默认，1小时至14天内用平台定时，其余用本地定时）、platform、local"`
+	Draft        bool     `json:"draft,omitempty" jsonschema:"是否只存入草稿箱不发布（可选），用于发布前让人审核，不能与schedule_at同时使用"`
+}
+
+// ListFeedsArgs 获取首页推荐的参数
+type ListFeedsArgs struct {
+	AttachImages      bool `json:"attach_images,omitempty" jsonschema:"是否附带封面缩略图（图片内容），供多模态模型查看封面画面"`
+	MaxImages         int  `json:"max_images,omitempty" jsonschema:"【仅当attach_images为true时生效】最多附带的图片数量，默认4"`
+	MaxImageDimension int  `json:"max_image_dimension,omitempty" jsonschema:"【仅当attach_images为true时生效】缩略图最长边像素，默认768"`
 }
 
 // SearchFeedsArgs 搜索内容的参数
@@ -39,6 +53,17 @@ type SearchFeedsArgs struct {
 	Filters FilterOption `json:"filters,omitempty" jsonschema:"筛选选项"`
 }
 
+// SearchSuggestArgs 获取搜索联想词的参数
+type SearchSuggestArgs struct {
+	Keyword string `json:"keyword" jsonschema:"搜索关键词前缀，返回搜索框下拉的联想词"`
+}
+
+// TopicFeedsArgs 获取话题页笔记的参数
+type TopicFeedsArgs struct {
+	TopicID string `json:"topic_id" jsonschema:"话题ID，从笔记详情的topics/tagList字段获取；id为空的话题只出现在正文里，不能用于本工具"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"返回的笔记数量，默认20"`
+}
+
 // FilterOption 筛选选项结构体
 type FilterOption struct {
 	SortBy      string `json:"sort_by,omitempty" jsonschema:"排序依据: 综合|最新|最多点赞|最多评论|最多收藏,默认为'综合'"`
@@ -50,13 +75,109 @@ type FilterOption struct {
 
 // FeedDetailArgs 获取Feed详情的参数
 type FeedDetailArgs struct {
-	FeedID           string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
-	XsecToken        string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
-	LoadAllComments  bool   `json:"load_all_comments,omitempty" jsonschema:"是否加载全部评论。false仅返回前10条一级评论（默认），true滚动加载更多评论"`
-	Limit            int    `json:"limit,omitempty" jsonschema:"【仅当load_all_comments为true时生效】限制加载的一级评论数量。例如20表示最多加载20条，默认20"`
-	ClickMoreReplies bool   `json:"click_more_replies,omitempty" jsonschema:"【仅当load_all_comments为true时生效】是否展开二级回复。true展开子评论，false不展开（默认）"`
-	ReplyLimit       int    `json:"reply_limit,omitempty" jsonschema:"【仅当click_more_replies为true时生效】跳过回复数过多的评论。例如10表示跳过超过10条回复的，默认10"`
-	ScrollSpeed      string `json:"scroll_speed,omitempty" jsonschema:"【仅当load_all_comments为true时生效】滚动速度slow慢速、normal正常、fast快速"`
+	FeedID            string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken         string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	LoadAllComments   bool   `json:"load_all_comments,omitempty" jsonschema:"是否加载全部评论。false仅返回前10条一级评论（默认），true滚动加载更多评论"`
+	Limit             int    `json:"limit,omitempty" jsonschema:"【仅当load_all_comments为true时生效】限制加载的一级评论数量。例如20表示最多加载20条，默认20"`
+	ClickMoreReplies  bool   `json:"click_more_replies,omitempty" jsonschema:"【仅当load_all_comments为true时生效】是否展开二级回复。true展开子评论，false不展开（默认）"`
+	ReplyLimit        int    `json:"reply_limit,omitempty" jsonschema:"【仅当click_more_replies为true时生效】跳过回复数过多的评论。例如10表示跳过超过10条回复的，默认10"`
+	ScrollSpeed       string `json:"scroll_speed,omitempty" jsonschema:"【仅当load_all_comments为true时生效】滚动速度slow慢速、normal正常、fast快速"`
+	DownloadMedia     bool   `json:"download_media,omitempty" jsonschema:"是否将笔记图片（视频笔记为封面）下载到本地，本地路径在media_files字段返回"`
+	AttachImages      bool   `json:"attach_images,omitempty" jsonschema:"是否附带笔记图片缩略图（图片内容），供多模态模型查看图片画面"`
+	MaxImages         int    `json:"max_images,omitempty" jsonschema:"【仅当attach_images为true时生效】最多附带的图片数量，默认4"`
+	MaxImageDimension int    `json:"max_image_dimension,omitempty" jsonschema:"【仅当attach_images为true时生效】缩略图最长边像素，默认768"`
+	CommentDigest     bool   `json:"comment_digest,omitempty" jsonschema:"是否附带评论区摘要：高赞评论、语言分布、高频关键词、评论者的提问和IP属地分布"`
+	DigestOnly        bool   `json:"digest_only,omitempty" jsonschema:"只返回评论区摘要、不返回评论原文，评论很多时可节省上下文"`
+}
+
+// ListCommentsArgs 分页读取评论的参数
+type ListCommentsArgs struct {
+	FeedID    string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	Cursor    string `json:"cursor,omitempty" jsonschema:"上一页返回的cursor，为空表示读取第一页"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"每页一级评论数量，默认10，最多50"`
+}
+
+// CommentThreadArgs 读取评论回复的参数
+type CommentThreadArgs struct {
+	FeedID    string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	CommentID string `json:"comment_id" jsonschema:"一级评论ID或其下某条回复的ID，从评论列表获取"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"最多返回的回复数量，默认20，最多100"`
+}
+
+// DeleteCommentArgs 删除评论的参数
+type DeleteCommentArgs struct {
+	FeedID    string `json:"feed_id" jsonschema:"小红书笔记ID"`
+	XsecToken string `json:"xsec_token" jsonschema:"访问令牌"`
+	CommentID string `json:"comment_id" jsonschema:"要删除的评论ID，只能是当前登录账号发表的评论"`
+}
+
+// LikeCommentArgs 评论点赞的参数
+type LikeCommentArgs struct {
+	FeedID    string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	CommentID string `json:"comment_id" jsonschema:"一级评论ID或回复ID，从评论列表获取"`
+	Unlike    bool   `json:"unlike,omitempty" jsonschema:"是否取消点赞，true为取消点赞，false或未设置则为点赞"`
+}
+
+// FollowUserArgs 关注用户的参数
+type FollowUserArgs struct {
+	UserID    string `json:"user_id,omitempty" jsonschema:"要关注的用户ID，从Feed列表或用户主页获取；与feed_id二选一"`
+	FeedID    string `json:"feed_id,omitempty" jsonschema:"笔记ID，传入时关注该笔记的作者；与user_id二选一"`
+	XsecToken string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	Unfollow  bool   `json:"unfollow,omitempty" jsonschema:"是否取消关注，true为取消关注，false或未设置则为关注"`
+}
+
+// ListFollowUsersArgs 分页读取粉丝/关注的参数
+type ListFollowUsersArgs struct {
+	Cursor string `json:"cursor,omitempty" jsonschema:"上一页返回的cursor，为空表示读取第一页"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"每页用户数量，默认20，最多100"`
+}
+
+// ScheduledPublishArgs 取消本地定时发布任务的参数
+type ScheduledPublishArgs struct {
+	JobID string `json:"job_id" jsonschema:"定时发布任务ID，从list_scheduled_publishes获取"`
+}
+
+// RescheduleArgs 修改本地定时发布时间的参数
+type RescheduleArgs struct {
+	JobID      string `json:"job_id" jsonschema:"定时发布任务ID，从list_scheduled_publishes获取"`
+	ScheduleAt string `json:"schedule_at" jsonschema:"新的发布时间，ISO8601格式如 2024-01-20T10:30:00+08:00，可以是任意未来时间"`
+}
+
+// ListDraftsArgs 获取草稿箱的参数
+type ListDraftsArgs struct {
+	Kind string `json:"kind,omitempty" jsonschema:"草稿类型：image（图文，默认）或 video（视频）"`
+}
+
+// ListMyNotesArgs 获取我的笔记的参数
+type ListMyNotesArgs struct {
+	Limit int `json:"limit,omitempty" jsonschema:"最多返回的笔记数量，默认20，最多100"`
+}
+
+// CreatorStatsTrendArgs 查询创作中心数据变化的参数
+type CreatorStatsTrendArgs struct {
+	Since string `json:"since,omitempty" jsonschema:"以这个时间的数据为基准，支持 RFC3339、2006-01-02、Unix 秒或 168h 这样的时长；为空时以最早的快照为基准"`
+}
+
+// NotificationsArgs 读取通知的参数
+type NotificationsArgs struct {
+	Tabs  []string `json:"tabs,omitempty" jsonschema:"要读取的标签：comments（评论和@）、likes（赞和收藏）、follows（新增关注），不传表示全部"`
+	Since string   `json:"since,omitempty" jsonschema:"只返回晚于该时间的通知，可以是RFC3339时间、2006-01-02日期、Unix秒，或24h这样的时长；不传不过滤"`
+	Limit int      `json:"limit,omitempty" jsonschema:"每个标签最多返回的条数，默认30，最多100"`
+}
+
+// ReadConversationArgs 读取私信会话的参数
+type ReadConversationArgs struct {
+	UserID string `json:"user_id" jsonschema:"会话对方的用户ID，从私信会话列表获取"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"返回最近的消息条数，默认20，最多100"`
+}
+
+// SendMessageArgs 发送私信的参数
+type SendMessageArgs struct {
+	UserID  string `json:"user_id" jsonschema:"接收私信的用户ID，只能是已有会话的用户"`
+	Content string `json:"content" jsonschema:"私信文字内容，最多500字"`
 }
 
 // UserProfileArgs 获取用户主页的参数
@@ -67,18 +188,22 @@ type UserProfileArgs struct {
 
 // PostCommentArgs 发表评论的参数
 type PostCommentArgs struct {
-	FeedID    string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
-	XsecToken string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
-	Content   string `json:"content" jsonschema:"评论内容"`
+	FeedID    string   `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken string   `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	Content   string   `json:"content" jsonschema:"评论内容，可包含 [笑哭R] 形式的表情，名称见 list_emojis"`
+	Mentions  []string `json:"mentions,omitempty" jsonschema:"要@的用户ID或昵称列表（可选），会插入到评论末尾"`
+	Image     string   `json:"image,omitempty" jsonschema:"附带一张图片（可选），支持HTTP/HTTPS图片链接或本地图片绝对路径，格式为jpg/png/webp/gif"`
 }
 
 // ReplyCommentArgs 回复评论的参数
 type ReplyCommentArgs struct {
-	FeedID    string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
-	XsecToken string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
-	CommentID string `json:"comment_id,omitempty" jsonschema:"目标评论ID，从评论列表获取"`
-	UserID    string `json:"user_id,omitempty" jsonschema:"目标评论用户ID，从评论列表获取"`
-	Content   string `json:"content" jsonschema:"回复内容"`
+	FeedID    string   `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken string   `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	CommentID string   `json:"comment_id,omitempty" jsonschema:"目标评论ID，从评论列表获取"`
+	UserID    string   `json:"user_id,omitempty" jsonschema:"目标评论用户ID，从评论列表获取"`
+	Content   string   `json:"content" jsonschema:"回复内容，可包含 [笑哭R] 形式的表情，名称见 list_emojis"`
+	Mentions  []string `json:"mentions,omitempty" jsonschema:"要@的用户ID或昵称列表（可选），会插入到回复末尾"`
+	Image     string   `json:"image,omitempty" jsonschema:"附带一张图片（可选），支持HTTP/HTTPS图片链接或本地图片绝对路径，格式为jpg/png/webp/gif"`
 }
 
 // LikeFeedArgs 点赞参数
@@ -209,11 +334,14 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 		withPanicRecovery("publish_content", func(ctx context.Context, req *mcp.CallToolRequest, args PublishContentArgs) (*mcp.CallToolResult, any, error) {
 			// 转换参数格式到现有的 handler
 			argsMap := map[string]interface{}{
-				"title":       args.Title,
-				"content":     args.Content,
-				"images":      convertStringsToInterfaces(args.Images),
-				"tags":        convertStringsToInterfaces(args.Tags),
-				"schedule_at": args.ScheduleAt,
+				"title":         args.Title,
+				"content":       args.Content,
+				"images":        convertStringsToInterfaces(args.Images),
+				"tags":          convertStringsToInterfaces(args.Tags),
+				"mentions":      convertStringsToInterfaces(args.Mentions),
+				"schedule_at":   args.ScheduleAt,
+				"schedule_mode": args.ScheduleMode,
+				"draft":         args.Draft,
 			}
 			result := appServer.handlePublishContent(ctx, argsMap)
 			return convertToMCPResult(result), nil, nil
@@ -224,14 +352,14 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 	mcp.AddTool(server,
 		&mcp.Tool{
 			Name:        "list_feeds",
-			Description: "获取首页 Feeds 列表",
+			Description: "获取首页 Feeds 列表，可选附带封面缩略图",
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Feeds",
 				ReadOnlyHint: true,
 			},
 		},
-		withPanicRecovery("list_feeds", func(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
-			result := appServer.handleListFeeds(ctx)
+		withPanicRecovery("list_feeds", func(ctx context.Context, req *mcp.CallToolRequest, args ListFeedsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListFeeds(ctx, args)
 			return convertToMCPResult(result), nil, nil
 		}),
 	)
@@ -256,7 +384,7 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 	mcp.AddTool(server,
 		&mcp.Tool{
 			Name:        "get_feed_detail",
-			Description: "获取小红书笔记详情，返回笔记内容、图片、作者信息、互动数据（点赞/收藏/分享数）及评论列表。默认返回前10条一级评论，如需更多评论请设置load_all_comments=true",
+			Description: "获取小红书笔记详情，返回笔记内容、图片、视频/实况图片地址、话题标签、@提及、作者信息、互动数据（点赞/收藏/分享数）及评论列表。默认返回前10条一级评论，如需更多评论请设置load_all_comments=true",
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Feed Detail",
 				ReadOnlyHint: true,
@@ -267,6 +395,15 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 				"feed_id":           args.FeedID,
 				"xsec_token":        args.XsecToken,
 				"load_all_comments": args.LoadAllComments,
+				"download_media":    args.DownloadMedia,
+				"attach_images":     args.AttachImages,
+				"comment_digest":    args.CommentDigest,
+				"digest_only":       args.DigestOnly,
+			}
+
+			if args.AttachImages {
+				argsMap["max_images"] = args.MaxImages
+				argsMap["max_image_dimension"] = args.MaxImageDimension
 			}
 
 			// 只有当 load_all_comments=true 时，才处理其他参数
@@ -332,6 +469,8 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 				"feed_id":    args.FeedID,
 				"xsec_token": args.XsecToken,
 				"content":    args.Content,
+				"mentions":   convertStringsToInterfaces(args.Mentions),
+				"image":      args.Image,
 			}
 			result := appServer.handlePostComment(ctx, argsMap)
 			return convertToMCPResult(result), nil, nil
@@ -362,6 +501,8 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 				"comment_id": args.CommentID,
 				"user_id":    args.UserID,
 				"content":    args.Content,
+				"mentions":   convertStringsToInterfaces(args.Mentions),
+				"image":      args.Image,
 			}
 			result := appServer.handleReplyComment(ctx, argsMap)
 			return convertToMCPResult(result), nil, nil
@@ -380,11 +521,14 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 		},
 		withPanicRecovery("publish_with_video", func(ctx context.Context, req *mcp.CallToolRequest, args PublishVideoArgs) (*mcp.CallToolResult, any, error) {
 			argsMap := map[string]interface{}{
-				"title":       args.Title,
-				"content":     args.Content,
-				"video":       args.Video,
-				"tags":        convertStringsToInterfaces(args.Tags),
-				"schedule_at": args.ScheduleAt,
+				"title":         args.Title,
+				"content":       args.Content,
+				"video":         args.Video,
+				"tags":          convertStringsToInterfaces(args.Tags),
+				"mentions":      convertStringsToInterfaces(args.Mentions),
+				"schedule_at":   args.ScheduleAt,
+				"schedule_mode": args.ScheduleMode,
+				"draft":         args.Draft,
 			}
 			result := appServer.handlePublishVideo(ctx, argsMap)
 			return convertToMCPResult(result), nil, nil
@@ -433,7 +577,358 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 		}),
 	)
 
-	logrus.Infof("Registered %d MCP tools", 13)
+	// 工具 14: 话题页笔记
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "topic_feeds",
+			Description: "打开小红书话题页，获取话题浏览量、笔记数及话题下的笔记列表",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Topic Feeds",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("topic_feeds", func(ctx context.Context, req *mcp.CallToolRequest, args TopicFeedsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleTopicFeeds(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 15: 搜索联想词
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "search_suggest",
+			Description: "获取搜索框对关键词前缀的联想词，用于挑选更具体的搜索词",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Search Suggest",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("search_suggest", func(ctx context.Context, req *mcp.CallToolRequest, args SearchSuggestArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleSearchSuggest(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 16: 热搜榜
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "trending_keywords",
+			Description: "获取小红书搜索框中的热搜榜关键词及热度",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Trending Keywords",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("trending_keywords", func(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleTrendingKeywords(ctx)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 17: 分页读取评论
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_comments",
+			Description: "分页读取笔记的一级评论。首次不传cursor，之后传入上一页返回的cursor读取下一页，hasMore为false表示已读完",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Comments",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_comments", func(ctx context.Context, req *mcp.CallToolRequest, args ListCommentsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListComments(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 18: 读取评论回复
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "comment_thread",
+			Description: "只展开指定评论的回复，返回以一级评论为根、按回复关系嵌套的回复树",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Comment Thread",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("comment_thread", func(ctx context.Context, req *mcp.CallToolRequest, args CommentThreadArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleCommentThread(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 19: 删除评论
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "delete_comment",
+			Description: "删除当前登录账号自己发表的评论或回复，不能删除别人的评论",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Delete Comment",
+				DestructiveHint: boolPtr(true),
+			},
+		},
+		withPanicRecovery("delete_comment", func(ctx context.Context, req *mcp.CallToolRequest, args DeleteCommentArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleDeleteComment(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 20: 评论点赞
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "like_comment",
+			Description: "为笔记下的某条评论或回复点赞或取消点赞，已处于目标状态时不会重复点击",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Like Comment",
+				DestructiveHint: boolPtr(true),
+			},
+		},
+		withPanicRecovery("like_comment", func(ctx context.Context, req *mcp.CallToolRequest, args LikeCommentArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleLikeComment(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 21: 评论表情列表
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_emojis",
+			Description: "获取评论框表情面板中可用的表情名称（如 [笑哭R]）。在评论或回复内容中直接写这些名称，会通过表情面板插入平台表情",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Emojis",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_emojis", func(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListEmojis(ctx)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 22: 关注用户
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "follow_user",
+			Description: "关注或取消关注用户。传 user_id 在用户主页操作，传 feed_id 则关注该笔记的作者；已处于目标状态时不会重复点击，返回中的 changed 表示是否实际改变了关注状态",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Follow User",
+				DestructiveHint: boolPtr(true),
+			},
+		},
+		withPanicRecovery("follow_user", func(ctx context.Context, req *mcp.CallToolRequest, args FollowUserArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleFollowUser(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 23: 我的粉丝
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_followers",
+			Description: "分页读取当前登录账号的粉丝，最新关注的在前。返回昵称、用户ID、xsecToken 以及是否互相关注；首次不传cursor，之后传入上一页返回的cursor",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Followers",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_followers", func(ctx context.Context, req *mcp.CallToolRequest, args ListFollowUsersArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListFollowUsers(ctx, xiaohongshu.FollowListFollowers, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 24: 我的关注
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_followings",
+			Description: "分页读取当前登录账号关注的用户，返回昵称、用户ID、xsecToken 以及是否互相关注；首次不传cursor，之后传入上一页返回的cursor",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Followings",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_followings", func(ctx context.Context, req *mcp.CallToolRequest, args ListFollowUsersArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListFollowUsers(ctx, xiaohongshu.FollowListFollowings, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 25: 私信会话列表
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_conversations",
+			Description: "获取私信会话列表（最近的在前），包含对方昵称、用户ID、最后一条消息和未读数。私信功能需启动时开启，允许名单模式下只返回名单中的会话",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Conversations",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_conversations", func(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListConversations(ctx)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 26: 读取私信
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "read_conversation",
+			Description: "读取与某个用户的私信会话中最近的消息，按时间从旧到新，fromMe 表示是否为自己发出",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Read Conversation",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("read_conversation", func(ctx context.Context, req *mcp.CallToolRequest, args ReadConversationArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleReadConversation(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 27: 发送私信
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "send_message",
+			Description: "在已有私信会话中给对方发送一条文字私信，发送后会确认消息出现在会话中",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Send Message",
+				DestructiveHint: boolPtr(true),
+			},
+		},
+		withPanicRecovery("send_message", func(ctx context.Context, req *mcp.CallToolRequest, args SendMessageArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleSendMessage(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 28: 通知
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "get_notifications",
+			Description: "读取通知页：评论和@、赞和收藏、新增关注。每条通知包含类型（comment/mention/like/collect/follow）、用户、内容、相关笔记和时间；用 since 只看某个时间之后的新通知",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Get Notifications",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("get_notifications", func(ctx context.Context, req *mcp.CallToolRequest, args NotificationsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleNotifications(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 29: 我的笔记
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_my_notes",
+			Description: "从创作中心获取当前账号最近发布的笔记，包含观看、点赞、评论、收藏、分享数据，以及是否仅自己可见和审核状态",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List My Notes",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_my_notes", func(ctx context.Context, req *mcp.CallToolRequest, args ListMyNotesArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListMyNotes(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 30: 采集创作中心数据
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "creator_stats",
+			Description: "从创作中心数据中心采集账号数据（曝光、观看、封面点击率、完播率、涨粉等）和最近笔记的数据，并保存为本地快照，用于之后查询趋势",
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Collect Creator Stats",
+			},
+		},
+		withPanicRecovery("creator_stats", func(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleCollectCreatorStats(ctx)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 31: 创作中心数据变化
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "creator_stats_trend",
+			Description: "根据本地保存的创作中心数据快照，计算从指定时间到最新一次采集之间总粉丝数和每篇笔记累计数据的增长；近7日等滚动周期数据只给出最新值",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Creator Stats Trend",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("creator_stats_trend", func(ctx context.Context, req *mcp.CallToolRequest, args CreatorStatsTrendArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleCreatorStatsTrend(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 32: 草稿箱列表
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_drafts",
+			Description: "获取创作中心草稿箱中的草稿（最近保存的在前），包含位置、标题、正文摘要和保存时间",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Drafts",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_drafts", func(ctx context.Context, req *mcp.CallToolRequest, args ListDraftsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListDrafts(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 33: 本地定时发布任务列表
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_scheduled_publishes",
+			Description: "获取本地定时发布任务（按执行时间排序），包含状态、执行时间和发布结果",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Scheduled Publishes",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_scheduled_publishes", func(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListScheduledPublishes(ctx)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 34: 取消本地定时发布
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "cancel_scheduled_publish",
+			Description: "取消还在等待执行的本地定时发布任务",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Cancel Scheduled Publish",
+				DestructiveHint: boolPtr(true),
+			},
+		},
+		withPanicRecovery("cancel_scheduled_publish", func(ctx context.Context, req *mcp.CallToolRequest, args ScheduledPublishArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleCancelScheduledPublish(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 35: 修改本地定时发布时间
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "reschedule_publish",
+			Description: "修改还在等待执行的本地定时发布任务的发布时间",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Reschedule Publish",
+				DestructiveHint: boolPtr(true),
+			},
+		},
+		withPanicRecovery("reschedule_publish", func(ctx context.Context, req *mcp.CallToolRequest, args RescheduleArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleReschedulePublish(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	logrus.Infof("Registered %d MCP tools", 35)
 }
 
 // convertToMCPResult 将自定义的 MCPToolResult 转换为官方 SDK 的格式
@@ -475,3 +970,15 @@ func convertStringsToInterfaces(strs []string) []interface{} {
 	}
 	return result
 }
+
+// convertInterfacesToStrings 辅助函数：从 []interface{} 参数中取出字符串
+func convertInterfacesToStrings(v interface{}) []string {
+	items, _ := v.([]interface{})
+	var result []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
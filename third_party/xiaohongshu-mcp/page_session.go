@@ -0,0 +1,121 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/xpzouying/headless_browser"
+)
+
+const (
+	// pageSessionIdle 分页会话空闲多久后关闭浏览器
+	pageSessionIdle = 5 * time.Minute
+	// pageSessionMax 同时保留的分页会话数，每个会话占用一个浏览器
+	pageSessionMax = 3
+)
+
+// pageSession 分页读取时保留的浏览器页面
+// 下一页请求在同一页面上从上次滚动到的位置继续加载，不必每页都从头滚动
+type pageSession[A any] struct {
+	browser *headless_browser.Browser
+	page    *rod.Page
+	action  A
+	usedAt  time.Time
+}
+
+func (s *pageSession[A]) close() {
+	s.page.Close()
+	s.browser.Close()
+}
+
+// pageSessions 按 key 保存空闲的分页会话
+// 正在使用的会话不在表中，同一 key 的并发请求会各自打开新页面
+type pageSessions[A any] struct {
+	mu        sync.Mutex
+	newAction func(page *rod.Page) A
+	items     map[string]*pageSession[A]
+}
+
+func newPageSessions[A any](newAction func(page *rod.Page) A) *pageSessions[A] {
+	return &pageSessions[A]{
+		newAction: newAction,
+		items:     make(map[string]*pageSession[A]),
+	}
+}
+
+// take 取出 key 对应的会话；resume 为 false 或没有空闲会话时打开新页面
+func (p *pageSessions[A]) take(key string, resume bool) *pageSession[A] {
+	p.mu.Lock()
+	s := p.items[key]
+	delete(p.items, key)
+	expired := p.expireLocked(time.Now())
+	p.mu.Unlock()
+	closeSessions(expired)
+
+	if s != nil && !resume {
+		s.close()
+		s = nil
+	}
+	if s == nil {
+		b := newBrowser()
+		page := b.NewPage()
+		s = &pageSession[A]{browser: b, page: page, action: p.newAction(page)}
+	}
+	return s
+}
+
+// put 归还会话供下一页使用；keep 为 false（出错或已读完）时直接关闭
+func (p *pageSessions[A]) put(key string, s *pageSession[A], keep bool) {
+	if !keep {
+		s.close()
+		return
+	}
+	s.usedAt = time.Now()
+
+	p.mu.Lock()
+	var evicted []*pageSession[A]
+	if old := p.items[key]; old != nil {
+		evicted = append(evicted, old)
+	}
+	p.items[key] = s
+	for len(p.items) > pageSessionMax {
+		oldestKey := ""
+		for k, it := range p.items {
+			if oldestKey == "" || it.usedAt.Before(p.items[oldestKey].usedAt) {
+				oldestKey = k
+			}
+		}
+		evicted = append(evicted, p.items[oldestKey])
+		delete(p.items, oldestKey)
+	}
+	p.mu.Unlock()
+	closeSessions(evicted)
+
+	time.AfterFunc(pageSessionIdle, p.sweep)
+}
+
+// sweep 关闭空闲超时的会话
+func (p *pageSessions[A]) sweep() {
+	p.mu.Lock()
+	expired := p.expireLocked(time.Now())
+	p.mu.Unlock()
+	closeSessions(expired)
+}
+
+func (p *pageSessions[A]) expireLocked(now time.Time) []*pageSession[A] {
+	var expired []*pageSession[A]
+	for k, s := range p.items {
+		if now.Sub(s.usedAt) >= pageSessionIdle {
+			expired = append(expired, s)
+			delete(p.items, k)
+		}
+	}
+	return expired
+}
+
+func closeSessions[A any](sessions []*pageSession[A]) {
+	for _, s := range sessions {
+		s.close()
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/h2non/filetype"
+	"github.com/h2non/filetype/types"
 	"github.com/pkg/errors"
 )
 
@@ -39,15 +40,48 @@ func NewImageDownloader(savePath string) *ImageDownloader {
 // DownloadImage 下载图片
 // 返回本地文件路径
 func (d *ImageDownloader) DownloadImage(imageURL string) (string, error) {
+	imageData, kind, err := d.fetchImage(imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	// 生成唯一文件名
+	fileName := d.generateFileName(imageURL, kind.Extension)
+	filePath := filepath.Join(d.savePath, fileName)
+
+	// 如果文件已存在，直接返回路径
+	if _, err := os.Stat(filePath); err == nil {
+		return filePath, nil
+	}
+
+	// 保存到文件
+	if err := os.WriteFile(filePath, imageData, 0644); err != nil {
+		return "", errors.Wrap(err, "failed to save image")
+	}
+
+	return filePath, nil
+}
+
+// FetchImage 下载图片到内存，返回图片数据和 MIME 类型
+func (d *ImageDownloader) FetchImage(imageURL string) ([]byte, string, error) {
+	imageData, kind, err := d.fetchImage(imageURL)
+	if err != nil {
+		return nil, "", err
+	}
+	return imageData, kind.MIME.Value, nil
+}
+
+// fetchImage 请求图片并校验格式
+func (d *ImageDownloader) fetchImage(imageURL string) ([]byte, types.Type, error) {
 	// 验证URL格式
 	if !d.isValidImageURL(imageURL) {
-		return "", errors.New("invalid image URL format")
+		return nil, types.Unknown, errors.New("invalid image URL format")
 	}
 
 	// 创建请求并设置请求头
 	req, err := http.NewRequest("GET", imageURL, nil)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to create request")
+		return nil, types.Unknown, errors.Wrap(err, "failed to create request")
 	}
 
 	// 设置 User-Agent，模拟浏览器请求
@@ -62,45 +96,31 @@ func (d *ImageDownloader) DownloadImage(imageURL string) (string, error) {
 	// 下载图片数据
 	resp, err := d.httpClient.Do(req)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to download image from %s", imageURL)
+		return nil, types.Unknown, errors.Wrapf(err, "failed to download image from %s", imageURL)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("download failed with status %d for URL: %s", resp.StatusCode, imageURL)
+		return nil, types.Unknown, fmt.Errorf("download failed with status %d for URL: %s", resp.StatusCode, imageURL)
 	}
 
 	// 读取图片数据
 	imageData, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to read image data")
+		return nil, types.Unknown, errors.Wrap(err, "failed to read image data")
 	}
 
 	// 检测图片格式
 	kind, err := filetype.Match(imageData)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to detect file type")
+		return nil, types.Unknown, errors.Wrap(err, "failed to detect file type")
 	}
 
 	if !filetype.IsImage(imageData) {
-		return "", errors.New("downloaded file is not a valid image")
+		return nil, types.Unknown, errors.New("downloaded file is not a valid image")
 	}
 
-	// 生成唯一文件名
-	fileName := d.generateFileName(imageURL, kind.Extension)
-	filePath := filepath.Join(d.savePath, fileName)
-
-	// 如果文件已存在，直接返回路径
-	if _, err := os.Stat(filePath); err == nil {
-		return filePath, nil
-	}
-
-	// 保存到文件
-	if err := os.WriteFile(filePath, imageData, 0644); err != nil {
-		return "", errors.Wrap(err, "failed to save image")
-	}
-
-	return filePath, nil
+	return imageData, kind, nil
 }
 
 // DownloadImages 批量下载图片
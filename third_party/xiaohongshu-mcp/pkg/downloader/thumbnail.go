@@ -0,0 +1,87 @@
+package downloader
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// 注册 gif/png 解码器
+	_ "image/gif"
+	_ "image/png"
+)
+
+const thumbnailJPEGQuality = 80
+
+// MakeThumbnail 将图片缩放到最长边不超过 maxDimension 像素，并编码为 JPEG
+// 标准库无法解码的格式（如 webp）在不超过 maxBytes 时原样返回，否则返回错误
+func MakeThumbnail(data []byte, mimeType string, maxDimension, maxBytes int) ([]byte, string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		if maxBytes > 0 && len(data) > maxBytes {
+			return nil, "", fmt.Errorf("无法缩放 %s 图片且大小 %d 超过限制 %d", mimeType, len(data), maxBytes)
+		}
+		return data, mimeType, nil
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if maxDimension > 0 && (width > maxDimension || height > maxDimension) {
+		if width >= height {
+			height = max(1, height*maxDimension/width)
+			width = maxDimension
+		} else {
+			width = max(1, width*maxDimension/height)
+			height = maxDimension
+		}
+		img = downscale(img, width, height)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+		return nil, "", fmt.Errorf("编码缩略图失败: %w", err)
+	}
+	if maxBytes > 0 && buf.Len() > maxBytes {
+		return nil, "", fmt.Errorf("缩略图大小 %d 超过限制 %d", buf.Len(), maxBytes)
+	}
+
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+// downscale 使用区域平均的方式缩小图片
+func downscale(src image.Image, width, height int) image.Image {
+	sb := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := sb.Min.Y + y*sb.Dy()/height
+		y1 := max(y0+1, sb.Min.Y+(y+1)*sb.Dy()/height)
+
+		for x := 0; x < width; x++ {
+			x0 := sb.Min.X + x*sb.Dx()/width
+			x1 := max(x0+1, sb.Min.X+(x+1)*sb.Dx()/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
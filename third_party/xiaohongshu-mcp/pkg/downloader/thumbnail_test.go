@@ -0,0 +1,80 @@
+package downloader
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestMakeThumbnail_Downscale(t *testing.T) {
+	data := encodeTestPNG(t, 400, 200)
+
+	out, mimeType, err := MakeThumbnail(data, "image/png", 100, 0)
+	if err != nil {
+		t.Fatalf("MakeThumbnail failed: %v", err)
+	}
+	if mimeType != "image/jpeg" {
+		t.Errorf("mimeType = %q, expected image/jpeg", mimeType)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(100, 50) {
+		t.Errorf("thumbnail size = %v, expected (100,50)", got)
+	}
+}
+
+func TestMakeThumbnail_KeepSmallImageSize(t *testing.T) {
+	data := encodeTestPNG(t, 60, 80)
+
+	out, _, err := MakeThumbnail(data, "image/png", 100, 0)
+	if err != nil {
+		t.Fatalf("MakeThumbnail failed: %v", err)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(60, 80) {
+		t.Errorf("thumbnail size = %v, expected (60,80)", got)
+	}
+}
+
+func TestMakeThumbnail_UndecodableFormat(t *testing.T) {
+	data := []byte("RIFF0000WEBPVP8 not-really-webp")
+
+	out, mimeType, err := MakeThumbnail(data, "image/webp", 100, 1024)
+	if err != nil {
+		t.Fatalf("small undecodable image should pass through: %v", err)
+	}
+	if mimeType != "image/webp" || !bytes.Equal(out, data) {
+		t.Errorf("undecodable image should be returned as-is")
+	}
+
+	if _, _, err := MakeThumbnail(data, "image/webp", 100, 8); err == nil {
+		t.Errorf("expected error when undecodable image exceeds maxBytes")
+	}
+}
@@ -0,0 +1,48 @@
+package xhsutil
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// countUnits 小红书计数文本中出现过的数量单位
+var countUnits = []struct {
+	suffix string
+	factor float64
+}{
+	{suffix: "亿", factor: 1e8},
+	{suffix: "万", factor: 1e4},
+	{suffix: "w", factor: 1e4},
+	{suffix: "千", factor: 1e3},
+	{suffix: "k", factor: 1e3},
+}
+
+// ParseCount 将小红书的计数文本转换为整数
+// 支持的格式："123"、"1,234"、"1.2万"、"3.5w"、"1亿"、"2千"、"10+"、"10万+"
+// 空字符串或无法识别的文本（如"赞"、"评论"等占位文字）返回 0
+func ParseCount(s string) int64 {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimRight(s, "+")
+	s = strings.ReplaceAll(s, ",", "")
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+
+	factor := 1.0
+	for _, u := range countUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			factor = u.factor
+			s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0
+	}
+
+	return int64(math.Round(n * factor))
+}
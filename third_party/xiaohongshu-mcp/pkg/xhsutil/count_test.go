@@ -0,0 +1,43 @@
+package xhsutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int64
+	}{
+		{name: "空字符串", input: "", want: 0},
+		{name: "纯空白", input: "  ", want: 0},
+		{name: "零", input: "0", want: 0},
+		{name: "普通整数", input: "999", want: 999},
+		{name: "前后空白", input: " 42 ", want: 42},
+		{name: "千分位逗号", input: "1,234", want: 1234},
+		{name: "整数万", input: "3万", want: 30000},
+		{name: "小数万", input: "1.2万", want: 12000},
+		{name: "两位小数万", input: "1.25万", want: 12500},
+		{name: "小写w", input: "3.5w", want: 35000},
+		{name: "大写W", input: "2W", want: 20000},
+		{name: "亿", input: "1.1亿", want: 110000000},
+		{name: "千", input: "2千", want: 2000},
+		{name: "小写k", input: "1.5k", want: 1500},
+		{name: "加号下限", input: "10+", want: 10},
+		{name: "万加号", input: "10万+", want: 100000},
+		{name: "数字与单位间有空格", input: "1.2 万", want: 12000},
+		{name: "占位文字-赞", input: "赞", want: 0},
+		{name: "占位文字-评论", input: "评论", want: 0},
+		{name: "负数视为无效", input: "-5", want: 0},
+		{name: "非法文本", input: "abc", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseCount(tt.input))
+		})
+	}
+}
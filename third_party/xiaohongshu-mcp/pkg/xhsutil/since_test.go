@@ -0,0 +1,36 @@
+package xhsutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.Local)
+
+	got, err := ParseSince("", now)
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	got, err = ParseSince("24h", now)
+	require.NoError(t, err)
+	assert.True(t, now.Add(-24*time.Hour).Equal(got))
+
+	got, err = ParseSince("2025-03-01", now)
+	require.NoError(t, err)
+	assert.True(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local).Equal(got))
+
+	got, err = ParseSince("2025-03-01T08:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1740816000), got.Unix())
+
+	got, err = ParseSince("1740816000", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1740816000), got.Unix())
+
+	_, err = ParseSince("yesterday", now)
+	assert.Error(t, err)
+}
@@ -46,10 +46,36 @@ func setupRoutes(appServer *AppServer) *gin.Engine {
 		api.GET("/feeds/list", appServer.listFeedsHandler)
 		api.GET("/feeds/search", appServer.searchFeedsHandler)
 		api.POST("/feeds/search", appServer.searchFeedsHandler)
+		api.GET("/search/suggest", appServer.searchSuggestHandler)
+		api.GET("/search/trending", appServer.trendingKeywordsHandler)
 		api.POST("/feeds/detail", appServer.getFeedDetailHandler)
+		api.POST("/feeds/comments", appServer.listCommentsHandler)
+		api.POST("/topic/feeds", appServer.topicFeedsHandler)
 		api.POST("/user/profile", appServer.userProfileHandler)
 		api.POST("/feeds/comment", appServer.postCommentHandler)
 		api.POST("/feeds/comment/reply", appServer.replyCommentHandler)
+		api.POST("/feeds/comment/thread", appServer.commentThreadHandler)
+		api.POST("/feeds/comment/delete", appServer.deleteCommentHandler)
+		api.POST("/feeds/comment/like", appServer.likeCommentHandler)
+		api.GET("/feeds/comment/emojis", appServer.listEmojisHandler)
+		api.POST("/user/follow", appServer.followUserHandler)
+		api.GET("/user/me/followers", appServer.myFollowersHandler)
+		api.GET("/user/me/followings", appServer.myFollowingsHandler)
+		api.GET("/schedule", appServer.listScheduledPublishesHandler)
+		api.POST("/schedule/cancel", appServer.cancelScheduledPublishHandler)
+		api.POST("/schedule/reschedule", appServer.rescheduleHandler)
+		api.GET("/drafts", appServer.listDraftsHandler)
+		api.POST("/drafts/publish", appServer.publishDraftHandler)
+		api.POST("/drafts/delete", appServer.deleteDraftHandler)
+		api.POST("/creator/stats", appServer.collectCreatorStatsHandler)
+		api.GET("/creator/stats/trend", appServer.creatorStatsTrendHandler)
+		api.GET("/notes/mine", appServer.myNotesHandler)
+		api.POST("/notes/delete", appServer.deleteNoteHandler)
+		api.POST("/notes/visibility", appServer.noteVisibilityHandler)
+		api.GET("/notifications", appServer.notificationsHandler)
+		api.GET("/messages/conversations", appServer.listConversationsHandler)
+		api.POST("/messages/conversation", appServer.readConversationHandler)
+		api.POST("/messages/send", appServer.sendMessageHandler)
 		api.GET("/user/me", appServer.myProfileHandler)
 	}
 
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 定时发布方式
+const (
+	ModeAuto     = "auto"     // 平台支持的时间范围内用平台定时，否则用本地定时
+	ModePlatform = "platform" // 只用平台定时
+	ModeLocal    = "local"    // 只用本地定时
+)
+
+// 平台定时发布支持的时间范围
+const (
+	PlatformMinDelay = time.Hour
+	PlatformMaxDelay = 14 * 24 * time.Hour
+)
+
+// UseLocal 判断定时发布时间 t 应交给本地任务还是平台定时，mode 为空时按 ModeAuto 处理
+func UseLocal(mode string, t, now time.Time) (bool, error) {
+	if !t.After(now) {
+		return false, fmt.Errorf("定时发布时间必须晚于当前时间，当前设置: %s", t.Format("2006-01-02 15:04"))
+	}
+	inPlatformWindow := !t.Before(now.Add(PlatformMinDelay)) && !t.After(now.Add(PlatformMaxDelay))
+
+	switch strings.TrimSpace(mode) {
+	case "", ModeAuto:
+		return !inPlatformWindow, nil
+	case ModeLocal:
+		return true, nil
+	case ModePlatform:
+		if t.Before(now.Add(PlatformMinDelay)) {
+			return false, fmt.Errorf("平台定时发布时间必须至少在1小时后，当前设置: %s，最早可选: %s",
+				t.Format("2006-01-02 15:04"), now.Add(PlatformMinDelay).Format("2006-01-02 15:04"))
+		}
+		if t.After(now.Add(PlatformMaxDelay)) {
+			return false, fmt.Errorf("平台定时发布时间不能超过14天，当前设置: %s，最晚可选: %s",
+				t.Format("2006-01-02 15:04"), now.Add(PlatformMaxDelay).Format("2006-01-02 15:04"))
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("未知的定时发布方式: %q，可选 auto、platform、local", mode)
+	}
+}
@@ -0,0 +1,326 @@
+// Package scheduler 在本地保存定时发布任务，到点后执行发布
+// 任务保存在 JSON 文件中，服务重启后继续执行
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	xhserrors "github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// 任务类型
+const (
+	KindImage = "image" // 图文
+	KindVideo = "video" // 视频
+)
+
+// Status 任务状态
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // 等待执行
+	StatusRunning  Status = "running"  // 正在发布
+	StatusDone     Status = "done"     // 发布成功
+	StatusFailed   Status = "failed"   // 发布失败
+	StatusCanceled Status = "canceled" // 已取消
+)
+
+// maxIdle 没有任务时的最长等待时间，避免系统休眠等导致计时偏差
+const maxIdle = time.Minute
+
+// maxLateness 任务最多允许推迟多久执行。服务停止或休眠期间错过时间太久的任务
+// 不再发布，而是标记为失败，由调用方决定是否重新安排
+const maxLateness = time.Hour
+
+// Job 一个定时发布任务
+type Job struct {
+	ID        string          `json:"id"`
+	Kind      string          `json:"kind"`
+	Title     string          `json:"title"`
+	RunAt     time.Time       `json:"runAt"`
+	Status    Status          `json:"status"`
+	Request   json.RawMessage `json:"request"` // 发布请求，到点后原样交给 Runner
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+// Runner 执行一个到点的任务，返回值会保存到任务的 Result 中
+type Runner func(ctx context.Context, job Job) (any, error)
+
+// Scheduler 按时间顺序逐个执行到点的任务
+type Scheduler struct {
+	store *Store
+	run   Runner
+	wake  chan struct{}
+
+	mu   sync.Mutex
+	jobs []*Job
+}
+
+// New 读取已保存的任务。上次退出时正在发布的任务标记为失败而不是重试，因为笔记可能已经发出
+func New(store *Store, run Runner) (*Scheduler, error) {
+	jobs, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scheduler{
+		store: store,
+		run:   run,
+		wake:  make(chan struct{}, 1),
+		jobs:  jobs,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, job := range s.jobs {
+		if job.Status == StatusRunning {
+			job.Status = StatusFailed
+			job.Error = "服务重启时发布被中断，请到小红书确认是否已发出"
+			job.UpdatedAt = time.Now()
+		}
+	}
+	return s, s.saveLocked()
+}
+
+// Start 在后台执行任务，直到 ctx 结束。错过时间不超过 maxLateness 的任务（如服务停止期间到点的）
+// 启动后立即执行，超过的标记为失败
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		for {
+			s.runDue(ctx, time.Now())
+
+			timer := time.NewTimer(s.nextWait(time.Now()))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-s.wake:
+				timer.Stop()
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// Add 新建任务，request 会序列化后保存
+func (s *Scheduler) Add(kind, title string, runAt time.Time, request any) (Job, error) {
+	raw, err := json.Marshal(request)
+	if err != nil {
+		return Job{}, err
+	}
+	id, err := newID()
+	if err != nil {
+		return Job{}, fmt.Errorf("生成任务 ID 失败: %w", err)
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:        id,
+		Kind:      kind,
+		Title:     title,
+		RunAt:     runAt,
+		Status:    StatusPending,
+		Request:   raw,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	s.mu.Lock()
+	s.jobs = append(s.jobs, job)
+	err = s.saveLocked()
+	s.mu.Unlock()
+	if err != nil {
+		return Job{}, err
+	}
+
+	s.notify()
+	logrus.Infof("已添加定时发布任务 %s: %s，执行时间 %s", job.ID, title, runAt.Format(time.RFC3339))
+	return *job, nil
+}
+
+// List 返回全部任务，按执行时间排序
+func (s *Scheduler) List() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].RunAt.Before(jobs[j].RunAt) })
+	return jobs
+}
+
+// Cancel 取消等待执行的任务
+func (s *Scheduler) Cancel(id string) (Job, error) {
+	return s.update(id, func(job *Job) {
+		job.Status = StatusCanceled
+	})
+}
+
+// Reschedule 修改等待执行的任务的执行时间
+func (s *Scheduler) Reschedule(id string, runAt time.Time) (Job, error) {
+	job, err := s.update(id, func(job *Job) {
+		job.RunAt = runAt
+	})
+	if err == nil {
+		s.notify()
+	}
+	return job, err
+}
+
+func (s *Scheduler) update(id string, fn func(job *Job)) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.findLocked(id)
+	if job == nil {
+		return Job{}, xhserrors.ErrScheduledJobNotFound
+	}
+	if job.Status != StatusPending {
+		return *job, fmt.Errorf("%w: 当前状态 %s", xhserrors.ErrScheduledJobNotPending, job.Status)
+	}
+	fn(job)
+	job.UpdatedAt = time.Now()
+	return *job, s.saveLocked()
+}
+
+// runDue 依次执行所有已到点的任务
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) {
+	for {
+		job, ok := s.claimDue(now)
+		if !ok {
+			return
+		}
+
+		logrus.Infof("执行定时发布任务 %s: %s", job.ID, job.Title)
+		result, err := s.run(ctx, job)
+		s.finish(job.ID, result, err)
+	}
+}
+
+// claimDue 取出最早到点的任务并标记为正在发布，错过时间超过 maxLateness 的任务标记为失败
+func (s *Scheduler) claimDue(now time.Time) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due *Job
+	stale := false
+	for _, job := range s.jobs {
+		if job.Status != StatusPending || job.RunAt.After(now) {
+			continue
+		}
+		if late := now.Sub(job.RunAt); late > maxLateness {
+			job.Status = StatusFailed
+			job.Error = fmt.Sprintf("错过发布时间 %v，超过允许的 %v，未发布", late.Round(time.Minute), maxLateness)
+			job.UpdatedAt = time.Now()
+			stale = true
+			logrus.Warnf("定时发布任务 %s 已过期: %s", job.ID, job.Error)
+			continue
+		}
+		if due == nil || job.RunAt.Before(due.RunAt) {
+			due = job
+		}
+	}
+	if due == nil {
+		if stale {
+			if err := s.saveLocked(); err != nil {
+				logrus.Errorf("保存定时发布任务失败: %v", err)
+			}
+		}
+		return Job{}, false
+	}
+
+	due.Status = StatusRunning
+	due.UpdatedAt = time.Now()
+	if err := s.saveLocked(); err != nil {
+		logrus.Errorf("保存定时发布任务失败: %v", err)
+	}
+	return *due, true
+}
+
+func (s *Scheduler) finish(id string, result any, runErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.findLocked(id)
+	if job == nil {
+		return
+	}
+	job.UpdatedAt = time.Now()
+	if runErr != nil {
+		job.Status = StatusFailed
+		job.Error = runErr.Error()
+		logrus.Errorf("定时发布任务 %s 失败: %v", id, runErr)
+	} else {
+		job.Status = StatusDone
+		logrus.Infof("定时发布任务 %s 完成", id)
+	}
+	if result != nil {
+		if raw, err := json.Marshal(result); err == nil {
+			job.Result = raw
+		}
+	}
+	if err := s.saveLocked(); err != nil {
+		logrus.Errorf("保存定时发布任务失败: %v", err)
+	}
+}
+
+// nextWait 距离下一个等待执行的任务的时间，最长 maxIdle
+func (s *Scheduler) nextWait(now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wait := maxIdle
+	for _, job := range s.jobs {
+		if job.Status != StatusPending {
+			continue
+		}
+		if d := job.RunAt.Sub(now); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) findLocked(id string) *Job {
+	for _, job := range s.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) saveLocked() error {
+	return s.store.Save(s.jobs)
+}
+
+func newID() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
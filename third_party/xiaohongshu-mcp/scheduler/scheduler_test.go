@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xhserrors "github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+func TestUseLocal(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	local, err := UseLocal("", now.Add(2*time.Hour), now)
+	require.NoError(t, err)
+	assert.False(t, local, "inside the platform window")
+
+	local, err = UseLocal("auto", now.Add(10*time.Minute), now)
+	require.NoError(t, err)
+	assert.True(t, local, "too soon for the platform")
+
+	local, err = UseLocal("", now.Add(30*24*time.Hour), now)
+	require.NoError(t, err)
+	assert.True(t, local, "too far for the platform")
+
+	local, err = UseLocal("local", now.Add(2*time.Hour), now)
+	require.NoError(t, err)
+	assert.True(t, local)
+
+	_, err = UseLocal("platform", now.Add(10*time.Minute), now)
+	assert.Error(t, err)
+
+	_, err = UseLocal("", now.Add(-time.Minute), now)
+	assert.Error(t, err, "past times are rejected")
+
+	_, err = UseLocal("later", now.Add(2*time.Hour), now)
+	assert.Error(t, err)
+}
+
+func TestSchedulerRunsDueJobs(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "schedule.json"))
+	ran := make(chan Job, 2)
+	s, err := New(store, func(ctx context.Context, job Job) (any, error) {
+		ran <- job
+		return map[string]string{"post_id": "n1"}, nil
+	})
+	require.NoError(t, err)
+
+	due, err := s.Add(KindImage, "晒太阳", time.Now().Add(-time.Second), map[string]string{"title": "晒太阳"})
+	require.NoError(t, err)
+	later, err := s.Add(KindImage, "散步", time.Now().Add(time.Hour), map[string]string{"title": "散步"})
+	require.NoError(t, err)
+
+	s.runDue(context.Background(), time.Now())
+	require.Len(t, ran, 1)
+	job := <-ran
+	assert.Equal(t, due.ID, job.ID)
+	assert.JSONEq(t, `{"title":"晒太阳"}`, string(job.Request))
+
+	jobs := s.List()
+	require.Len(t, jobs, 2)
+	assert.Equal(t, StatusDone, jobs[0].Status)
+	assert.JSONEq(t, `{"post_id":"n1"}`, string(jobs[0].Result))
+	assert.Equal(t, StatusPending, jobs[1].Status)
+	assert.Equal(t, later.ID, jobs[1].ID)
+
+	wait := s.nextWait(time.Now())
+	assert.True(t, wait > 0 && wait <= maxIdle)
+}
+
+func TestSchedulerFailsStaleJobs(t *testing.T) {
+	ran := make(chan Job, 2)
+	s, err := New(NewStore(filepath.Join(t.TempDir(), "schedule.json")), func(ctx context.Context, job Job) (any, error) {
+		ran <- job
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	stale, err := s.Add(KindImage, "昨天的", now.Add(-maxLateness-time.Minute), nil)
+	require.NoError(t, err)
+	late, err := s.Add(KindImage, "刚错过的", now.Add(-10*time.Minute), nil)
+	require.NoError(t, err)
+
+	s.runDue(context.Background(), now)
+	require.Len(t, ran, 1)
+	assert.Equal(t, late.ID, (<-ran).ID)
+
+	for _, job := range s.List() {
+		if job.ID == stale.ID {
+			assert.Equal(t, StatusFailed, job.Status)
+			assert.Contains(t, job.Error, "错过发布时间")
+		} else {
+			assert.Equal(t, StatusDone, job.Status)
+		}
+	}
+
+	// 重新加载后过期任务仍是失败状态
+	reloaded, err := NewStore(s.store.path).Load()
+	require.NoError(t, err)
+	for _, job := range reloaded {
+		if job.ID == stale.ID {
+			assert.Equal(t, StatusFailed, job.Status)
+		}
+	}
+}
+
+func TestSchedulerCancelAndReschedule(t *testing.T) {
+	s, err := New(NewStore(filepath.Join(t.TempDir(), "schedule.json")), func(ctx context.Context, job Job) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	job, err := s.Add(KindVideo, "看海", time.Now().Add(time.Hour), nil)
+	require.NoError(t, err)
+
+	at := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	job, err = s.Reschedule(job.ID, at)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(job.RunAt))
+
+	job, err = s.Cancel(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCanceled, job.Status)
+
+	_, err = s.Cancel(job.ID)
+	assert.ErrorIs(t, err, xhserrors.ErrScheduledJobNotPending)
+	_, err = s.Reschedule("missing", at)
+	assert.ErrorIs(t, err, xhserrors.ErrScheduledJobNotFound)
+}
+
+func TestSchedulerSurvivesRestart(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "schedule.json"))
+	noop := func(ctx context.Context, job Job) (any, error) { return nil, nil }
+
+	s, err := New(store, noop)
+	require.NoError(t, err)
+	pending, err := s.Add(KindImage, "晒太阳", time.Now().Add(time.Hour), nil)
+	require.NoError(t, err)
+	running, err := s.Add(KindImage, "散步", time.Now().Add(-time.Minute), nil)
+	require.NoError(t, err)
+	_, ok := s.claimDue(time.Now())
+	require.True(t, ok)
+
+	s, err = New(store, noop)
+	require.NoError(t, err)
+	jobs := s.List()
+	require.Len(t, jobs, 2)
+	assert.Equal(t, running.ID, jobs[0].ID)
+	assert.Equal(t, StatusFailed, jobs[0].Status, "interrupted publishes are not retried")
+	assert.Equal(t, pending.ID, jobs[1].ID)
+	assert.Equal(t, StatusPending, jobs[1].Status)
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Store 把全部任务保存在一个 JSON 文件中，写入时先写临时文件再替换
+type Store struct {
+	path string
+}
+
+func NewStore(path string) *Store {
+	if path == "" {
+		panic("path is required")
+	}
+	return &Store{path: path}
+}
+
+// Load 读取全部任务；文件不存在时返回空列表
+func (s *Store) Load() ([]*Job, error) {
+	raw, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "读取定时发布任务失败")
+	}
+
+	var jobs []*Job
+	if err := json.Unmarshal(raw, &jobs); err != nil {
+		return nil, errors.Wrap(err, "解析定时发布任务失败")
+	}
+	return jobs, nil
+}
+
+// Save 覆盖保存全部任务
+func (s *Store) Save(jobs []*Job) error {
+	if jobs == nil {
+		jobs = []*Job{}
+	}
+	raw, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return errors.Wrap(err, "创建任务目录失败")
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return errors.Wrap(err, "写入定时发布任务失败")
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -13,26 +16,42 @@ import (
 	"github.com/xpzouying/xiaohongshu-mcp/browser"
 	"github.com/xpzouying/xiaohongshu-mcp/configs"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
+	"github.com/xpzouying/xiaohongshu-mcp/creatorstats"
+	xhserrors "github.com/xpzouying/xiaohongshu-mcp/errors"
 	"github.com/xpzouying/xiaohongshu-mcp/pkg/downloader"
 	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+	"github.com/xpzouying/xiaohongshu-mcp/scheduler"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
 // XiaohongshuService 小红书业务服务
-type XiaohongshuService struct{}
+type XiaohongshuService struct {
+	scheduler    *scheduler.Scheduler // 本地定时发布，由 StartScheduler 启动
+	creatorStats *creatorstats.Store  // 创作中心数据快照，所有请求共用一个以串行读写
+
+	commentSessions *pageSessions[*xiaohongshu.FeedDetailAction] // 分页读取评论的页面，按笔记 ID 保存
+	followSessions  *pageSessions[*xiaohongshu.FollowListAction] // 分页读取粉丝/关注的页面，按列表类型保存
+}
 
 // NewXiaohongshuService 创建小红书服务实例
 func NewXiaohongshuService() *XiaohongshuService {
-	return &XiaohongshuService{}
+	return &XiaohongshuService{
+		creatorStats:    creatorstats.NewStore(configs.GetCreatorStatsPath()),
+		commentSessions: newPageSessions(xiaohongshu.NewFeedDetailAction),
+		followSessions:  newPageSessions(xiaohongshu.NewFollowListAction),
+	}
 }
 
 // PublishRequest 发布请求
 type PublishRequest struct {
-	Title      string   `json:"title" binding:"required"`
-	Content    string   `json:"content" binding:"required"`
-	Images     []string `json:"images" binding:"required,min=1"`
-	Tags       []string `json:"tags,omitempty"`
-	ScheduleAt string   `json:"schedule_at,omitempty"` // 定时发布时间，ISO8601格式，为空则立即发布
+	Title        string   `json:"title" binding:"required"`
+	Content      string   `json:"content" binding:"required"`
+	Images       []string `json:"images" binding:"required,min=1"`
+	Tags         []string `json:"tags,omitempty"`
+	Mentions     []string `json:"mentions,omitempty"`      // 要 @ 的用户 ID 或昵称
+	ScheduleAt   string   `json:"schedule_at,omitempty"`   // 定时发布时间，ISO8601格式，为空则立即发布
+	ScheduleMode string   `json:"schedule_mode,omitempty"` // 定时发布方式：auto（默认）、platform、local
+	Draft        bool     `json:"draft,omitempty"`         // 只填写内容并存入草稿箱，不发布
 }
 
 // LoginStatusResponse 登录状态响应
@@ -50,35 +69,113 @@ type LoginQrcodeResponse struct {
 
 // PublishResponse 发布响应
 type PublishResponse struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Images  int    `json:"images"`
-	Status  string `json:"status"`
-	PostID  string `json:"post_id,omitempty"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Images    int    `json:"images"`
+	Status    string `json:"status"`
+	PostID    string `json:"post_id,omitempty"`
+	XsecToken string `json:"xsec_token,omitempty"`
+	URL       string `json:"url,omitempty"`
+	JobID     string `json:"job_id,omitempty"` // 本地定时发布任务 ID
 }
 
 // PublishVideoRequest 发布视频请求（仅支持本地单个视频文件）
 type PublishVideoRequest struct {
-	Title      string   `json:"title" binding:"required"`
-	Content    string   `json:"content" binding:"required"`
-	Video      string   `json:"video" binding:"required"`
-	Tags       []string `json:"tags,omitempty"`
-	ScheduleAt string   `json:"schedule_at,omitempty"` // 定时发布时间，ISO8601格式，为空则立即发布
+	Title        string   `json:"title" binding:"required"`
+	Content      string   `json:"content" binding:"required"`
+	Video        string   `json:"video" binding:"required"`
+	Tags         []string `json:"tags,omitempty"`
+	Mentions     []string `json:"mentions,omitempty"`      // 要 @ 的用户 ID 或昵称
+	ScheduleAt   string   `json:"schedule_at,omitempty"`   // 定时发布时间，ISO8601格式，为空则立即发布
+	ScheduleMode string   `json:"schedule_mode,omitempty"` // 定时发布方式：auto（默认）、platform、local
+	Draft        bool     `json:"draft,omitempty"`         // 只填写内容并存入草稿箱，不发布
 }
 
 // PublishVideoResponse 发布视频响应
 type PublishVideoResponse struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Video   string `json:"video"`
-	Status  string `json:"status"`
-	PostID  string `json:"post_id,omitempty"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Video     string `json:"video"`
+	Status    string `json:"status"`
+	PostID    string `json:"post_id,omitempty"`
+	XsecToken string `json:"xsec_token,omitempty"`
+	URL       string `json:"url,omitempty"`
+	JobID     string `json:"job_id,omitempty"` // 本地定时发布任务 ID
 }
 
 // FeedsListResponse Feeds列表响应
 type FeedsListResponse struct {
-	Feeds []xiaohongshu.Feed `json:"feeds"`
-	Count int                `json:"count"`
+	Feeds  []xiaohongshu.Feed `json:"feeds"`
+	Count  int                `json:"count"`
+	Images []ImageAttachment  `json:"images,omitempty"` // 封面缩略图附件
+}
+
+// SearchSuggestResponse 搜索联想词响应
+type SearchSuggestResponse struct {
+	Keyword     string   `json:"keyword"`
+	Suggestions []string `json:"suggestions"`
+	Count       int      `json:"count"`
+}
+
+// TrendingKeywordsResponse 热搜榜响应
+type TrendingKeywordsResponse struct {
+	Keywords []xiaohongshu.TrendingKeyword `json:"keywords"`
+	Count    int                           `json:"count"`
+}
+
+// EmojiListResponse 评论表情列表响应
+type EmojiListResponse struct {
+	Emojis []string `json:"emojis"` // 如 [笑哭R]，可直接写进评论内容
+	Count  int      `json:"count"`
+}
+
+// ConversationListResponse 私信会话列表响应
+type ConversationListResponse struct {
+	Conversations []xiaohongshu.Conversation `json:"conversations"`
+	Count         int                        `json:"count"`
+	AllowlistMode bool                       `json:"allowlist_mode"` // 为 true 时只返回允许名单中的会话
+}
+
+// SendMessageResponse 发送私信响应
+type SendMessageResponse struct {
+	UserID  string `json:"user_id"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// NotificationsResponse 通知响应
+type NotificationsResponse struct {
+	Notifications []xiaohongshu.Notification `json:"notifications"`
+	Count         int                        `json:"count"`
+	Since         string                     `json:"since,omitempty"` // 实际使用的 since，RFC3339
+}
+
+// DraftsResponse 草稿箱列表响应
+type DraftsResponse struct {
+	Drafts []xiaohongshu.Draft `json:"drafts"`
+	Count  int                 `json:"count"`
+}
+
+// DraftActionResponse 发布或删除草稿的响应
+type DraftActionResponse struct {
+	Title   string `json:"title"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// MyNotesResponse 我的笔记列表响应
+type MyNotesResponse struct {
+	Notes []xiaohongshu.MyNote `json:"notes"`
+	Count int                  `json:"count"`
+}
+
+// NoteManageResponse 删除笔记或修改可见范围的响应
+type NoteManageResponse struct {
+	NoteID  string `json:"note_id"`
+	Private *bool  `json:"private,omitempty"` // 修改可见范围后的状态，删除笔记时为空
+	Changed bool   `json:"changed"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
 }
 
 // UserProfileResponse 用户主页响应
@@ -173,36 +270,42 @@ func (s *XiaohongshuService) PublishContent(ctx context.Context, req *PublishReq
 		return nil, fmt.Errorf("标题长度超过限制")
 	}
 
-	// 处理图片：下载URL图片或使用本地路径
-	imagePaths, err := s.processImages(req.Images)
-	if err != nil {
-		return nil, err
+	if req.Draft && req.ScheduleAt != "" {
+		return nil, fmt.Errorf("存入草稿箱时不能设置定时发布")
 	}
 
 	// 解析定时发布时间
-	var scheduleTime *time.Time
-	if req.ScheduleAt != "" {
-		t, err := time.Parse(time.RFC3339, req.ScheduleAt)
+	scheduleTime, localTime, err := parseSchedule(req.ScheduleAt, req.ScheduleMode)
+	if err != nil {
+		return nil, err
+	}
+	if localTime != nil {
+		// 添加任务时就下载并检查图片，不要等到发布时才发现图片不可用
+		imagePaths, err := prepareScheduledImages(req.Images)
 		if err != nil {
-			return nil, fmt.Errorf("定时发布时间格式错误，请使用 ISO8601 格式: %v", err)
-		}
-
-		// 校验定时发布时间范围：1小时至14天
-		now := time.Now()
-		minTime := now.Add(1 * time.Hour)
-		maxTime := now.Add(14 * 24 * time.Hour)
-
-		if t.Before(minTime) {
-			return nil, fmt.Errorf("定时发布时间必须至少在1小时后，当前设置: %s，最早可选: %s",
-				t.Format("2006-01-02 15:04"), minTime.Format("2006-01-02 15:04"))
+			return nil, err
 		}
-		if t.After(maxTime) {
-			return nil, fmt.Errorf("定时发布时间不能超过14天，当前设置: %s，最晚可选: %s",
-				t.Format("2006-01-02 15:04"), maxTime.Format("2006-01-02 15:04"))
+		// 到点后按立即发布执行
+		immediate := *req
+		immediate.ScheduleAt, immediate.ScheduleMode = "", ""
+		immediate.Images = imagePaths
+		job, err := s.addScheduledPublish(scheduler.KindImage, req.Title, *localTime, immediate)
+		if err != nil {
+			return nil, err
 		}
+		return &PublishResponse{
+			Title:   req.Title,
+			Content: req.Content,
+			Images:  len(req.Images),
+			Status:  "已加入本地定时发布",
+			JobID:   job.ID,
+		}, nil
+	}
 
-		scheduleTime = &t
-		logrus.Infof("设置定时发布时间: %s", t.Format("2006-01-02 15:04"))
+	// 处理图片：下载URL图片或使用本地路径
+	imagePaths, err := s.processImages(req.Images)
+	if err != nil {
+		return nil, err
 	}
 
 	// 构建发布内容
@@ -210,12 +313,15 @@ func (s *XiaohongshuService) PublishContent(ctx context.Context, req *PublishReq
 		Title:        req.Title,
 		Content:      req.Content,
 		Tags:         req.Tags,
+		Mentions:     req.Mentions,
 		ImagePaths:   imagePaths,
 		ScheduleTime: scheduleTime,
+		Draft:        req.Draft,
 	}
 
 	// 执行发布
-	if err := s.publishContent(ctx, content); err != nil {
+	note, err := s.publishContent(ctx, content)
+	if err != nil {
 		logrus.Errorf("发布内容失败: title=%s %v", content.Title, err)
 		return nil, err
 	}
@@ -224,7 +330,12 @@ func (s *XiaohongshuService) PublishContent(ctx context.Context, req *PublishReq
 		Title:   req.Title,
 		Content: req.Content,
 		Images:  len(imagePaths),
-		Status:  "发布完成",
+		Status:  publishStatus(req.Draft),
+	}
+	if note != nil {
+		response.PostID = note.NoteID
+		response.XsecToken = note.XsecToken
+		response.URL = note.URL
 	}
 
 	return response, nil
@@ -236,9 +347,27 @@ func (s *XiaohongshuService) processImages(images []string) ([]string, error) {
 	return processor.ProcessImages(images)
 }
 
+// prepareScheduledImages 下载本地定时发布任务的 URL 图片，确认所有图片都可读，
+// 返回绝对路径，任务保存这些路径而不是原始输入
+func prepareScheduledImages(images []string) ([]string, error) {
+	paths, err := downloader.NewImageProcessorWithDir(configs.GetScheduleImagesPath()).ProcessImages(images)
+	if err != nil {
+		return nil, err
+	}
+	for i, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("图片文件不存在或不可访问: %v", err)
+		}
+		if paths[i], err = filepath.Abs(path); err != nil {
+			return nil, fmt.Errorf("解析图片路径失败: %w", err)
+		}
+	}
+	return paths, nil
+}
+
 // publishContent 执行内容发布
-func (s *XiaohongshuService) publishContent(ctx context.Context, content xiaohongshu.PublishImageContent) error {
-	b := newBrowser()
+func (s *XiaohongshuService) publishContent(ctx context.Context, content xiaohongshu.PublishImageContent) (*xiaohongshu.PublishedNote, error) {
+	b := newPublishBrowser(content.Draft)
 	defer b.Close()
 
 	page := b.NewPage()
@@ -246,7 +375,7 @@ func (s *XiaohongshuService) publishContent(ctx context.Context, content xiaohon
 
 	action, err := xiaohongshu.NewPublishImageAction(page)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// 执行发布
@@ -260,6 +389,10 @@ func (s *XiaohongshuService) PublishVideo(ctx context.Context, req *PublishVideo
 		return nil, fmt.Errorf("标题长度超过限制")
 	}
 
+	if req.Draft && req.ScheduleAt != "" {
+		return nil, fmt.Errorf("存入草稿箱时不能设置定时发布")
+	}
+
 	// 本地视频文件校验
 	if req.Video == "" {
 		return nil, fmt.Errorf("必须提供本地视频文件")
@@ -269,29 +402,29 @@ func (s *XiaohongshuService) PublishVideo(ctx context.Context, req *PublishVideo
 	}
 
 	// 解析定时发布时间
-	var scheduleTime *time.Time
-	if req.ScheduleAt != "" {
-		t, err := time.Parse(time.RFC3339, req.ScheduleAt)
+	scheduleTime, localTime, err := parseSchedule(req.ScheduleAt, req.ScheduleMode)
+	if err != nil {
+		return nil, err
+	}
+	if localTime != nil {
+		video, err := filepath.Abs(req.Video)
 		if err != nil {
-			return nil, fmt.Errorf("定时发布时间格式错误，请使用 ISO8601 格式: %v", err)
+			return nil, fmt.Errorf("解析视频路径失败: %w", err)
 		}
-
-		// 校验定时发布时间范围：1小时至14天
-		now := time.Now()
-		minTime := now.Add(1 * time.Hour)
-		maxTime := now.Add(14 * 24 * time.Hour)
-
-		if t.Before(minTime) {
-			return nil, fmt.Errorf("定时发布时间必须至少在1小时后，当前设置: %s，最早可选: %s",
-				t.Format("2006-01-02 15:04"), minTime.Format("2006-01-02 15:04"))
-		}
-		if t.After(maxTime) {
-			return nil, fmt.Errorf("定时发布时间不能超过14天，当前设置: %s，最晚可选: %s",
-				t.Format("2006-01-02 15:04"), maxTime.Format("2006-01-02 15:04"))
+		immediate := *req
+		immediate.ScheduleAt, immediate.ScheduleMode = "", ""
+		immediate.Video = video
+		job, err := s.addScheduledPublish(scheduler.KindVideo, req.Title, *localTime, immediate)
+		if err != nil {
+			return nil, err
 		}
-
-		scheduleTime = &t
-		logrus.Infof("设置定时发布时间: %s", t.Format("2006-01-02 15:04"))
+		return &PublishVideoResponse{
+			Title:   req.Title,
+			Content: req.Content,
+			Video:   req.Video,
+			Status:  "已加入本地定时发布",
+			JobID:   job.ID,
+		}, nil
 	}
 
 	// 构建发布内容
@@ -299,12 +432,15 @@ func (s *XiaohongshuService) PublishVideo(ctx context.Context, req *PublishVideo
 		Title:        req.Title,
 		Content:      req.Content,
 		Tags:         req.Tags,
+		Mentions:     req.Mentions,
 		VideoPath:    req.Video,
 		ScheduleTime: scheduleTime,
+		Draft:        req.Draft,
 	}
 
 	// 执行发布
-	if err := s.publishVideo(ctx, content); err != nil {
+	note, err := s.publishVideo(ctx, content)
+	if err != nil {
 		return nil, err
 	}
 
@@ -312,14 +448,19 @@ func (s *XiaohongshuService) PublishVideo(ctx context.Context, req *PublishVideo
 		Title:   req.Title,
 		Content: req.Content,
 		Video:   req.Video,
-		Status:  "发布完成",
+		Status:  publishStatus(req.Draft),
+	}
+	if note != nil {
+		resp.PostID = note.NoteID
+		resp.XsecToken = note.XsecToken
+		resp.URL = note.URL
 	}
 	return resp, nil
 }
 
 // publishVideo 执行视频发布
-func (s *XiaohongshuService) publishVideo(ctx context.Context, content xiaohongshu.PublishVideoContent) error {
-	b := newBrowser()
+func (s *XiaohongshuService) publishVideo(ctx context.Context, content xiaohongshu.PublishVideoContent) (*xiaohongshu.PublishedNote, error) {
+	b := newPublishBrowser(content.Draft)
 	defer b.Close()
 
 	page := b.NewPage()
@@ -327,12 +468,185 @@ func (s *XiaohongshuService) publishVideo(ctx context.Context, content xiaohongs
 
 	action, err := xiaohongshu.NewPublishVideoAction(page)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	return action.PublishVideo(ctx, content)
 }
 
+// parseSchedule 解析定时发布时间，按 mode 决定交给平台定时还是本地定时
+// 返回的两个时间至多一个非空，都为空表示立即发布
+func parseSchedule(scheduleAt, mode string) (platform, local *time.Time, err error) {
+	if scheduleAt == "" {
+		return nil, nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, scheduleAt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("定时发布时间格式错误，请使用 ISO8601 格式: %v", err)
+	}
+
+	useLocal, err := scheduler.UseLocal(mode, t, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	if useLocal {
+		logrus.Infof("设置本地定时发布时间: %s", t.Format("2006-01-02 15:04"))
+		return nil, &t, nil
+	}
+	logrus.Infof("设置定时发布时间: %s", t.Format("2006-01-02 15:04"))
+	return &t, nil, nil
+}
+
+// StartScheduler 读取本地定时发布任务并在后台执行
+func (s *XiaohongshuService) StartScheduler(ctx context.Context) error {
+	sched, err := scheduler.New(scheduler.NewStore(configs.GetSchedulePath()), s.runScheduledPublish)
+	if err != nil {
+		return err
+	}
+	s.scheduler = sched
+	sched.Start(ctx)
+	return nil
+}
+
+func (s *XiaohongshuService) addScheduledPublish(kind, title string, runAt time.Time, req any) (scheduler.Job, error) {
+	if s.scheduler == nil {
+		return scheduler.Job{}, fmt.Errorf("本地定时发布未启动")
+	}
+	return s.scheduler.Add(kind, title, runAt, req)
+}
+
+// runScheduledPublish 执行到点的本地定时发布任务
+func (s *XiaohongshuService) runScheduledPublish(ctx context.Context, job scheduler.Job) (any, error) {
+	switch job.Kind {
+	case scheduler.KindImage:
+		var req PublishRequest
+		if err := json.Unmarshal(job.Request, &req); err != nil {
+			return nil, fmt.Errorf("解析发布请求失败: %w", err)
+		}
+		return s.PublishContent(ctx, &req)
+	case scheduler.KindVideo:
+		var req PublishVideoRequest
+		if err := json.Unmarshal(job.Request, &req); err != nil {
+			return nil, fmt.Errorf("解析发布请求失败: %w", err)
+		}
+		return s.PublishVideo(ctx, &req)
+	default:
+		return nil, fmt.Errorf("未知的任务类型: %s", job.Kind)
+	}
+}
+
+// ScheduledPublishesResponse 本地定时发布任务列表
+type ScheduledPublishesResponse struct {
+	Jobs  []scheduler.Job `json:"jobs"`
+	Count int             `json:"count"`
+}
+
+// ListScheduledPublishes 返回全部本地定时发布任务
+func (s *XiaohongshuService) ListScheduledPublishes() (*ScheduledPublishesResponse, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("本地定时发布未启动")
+	}
+	jobs := s.scheduler.List()
+	return &ScheduledPublishesResponse{Jobs: jobs, Count: len(jobs)}, nil
+}
+
+// CancelScheduledPublish 取消等待执行的本地定时发布任务
+func (s *XiaohongshuService) CancelScheduledPublish(jobID string) (*scheduler.Job, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("本地定时发布未启动")
+	}
+	job, err := s.scheduler.Cancel(jobID)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RescheduleScheduledPublish 修改本地定时发布任务的执行时间，可以是任意未来时间
+func (s *XiaohongshuService) RescheduleScheduledPublish(jobID, scheduleAt string) (*scheduler.Job, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("本地定时发布未启动")
+	}
+	t, err := time.Parse(time.RFC3339, scheduleAt)
+	if err != nil {
+		return nil, fmt.Errorf("定时发布时间格式错误，请使用 ISO8601 格式: %v", err)
+	}
+	if !t.After(time.Now()) {
+		return nil, fmt.Errorf("定时发布时间必须晚于当前时间，当前设置: %s", t.Format("2006-01-02 15:04"))
+	}
+	job, err := s.scheduler.Reschedule(jobID, t)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// publishStatus 发布响应中的状态文字
+func publishStatus(draft bool) string {
+	if draft {
+		return "已存入草稿箱"
+	}
+	return "发布完成"
+}
+
+// ListDrafts 返回草稿箱中的草稿
+func (s *XiaohongshuService) ListDrafts(ctx context.Context, kind string) (*DraftsResponse, error) {
+	draftKind, err := xiaohongshu.ParseDraftKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newDraftBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	drafts, err := xiaohongshu.NewDraftAction(page).ListDrafts(ctx, draftKind)
+	if err != nil {
+		return nil, err
+	}
+	return &DraftsResponse{Drafts: drafts, Count: len(drafts)}, nil
+}
+
+// PublishDraft 发布草稿箱中的草稿
+func (s *XiaohongshuService) PublishDraft(ctx context.Context, req *DraftRequest) (*DraftActionResponse, error) {
+	kind, err := xiaohongshu.ParseDraftKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newDraftBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	if err := xiaohongshu.NewDraftAction(page).PublishDraft(ctx, kind, *req.Index, req.Title); err != nil {
+		return nil, err
+	}
+	return &DraftActionResponse{Title: req.Title, Success: true, Message: "草稿发布成功"}, nil
+}
+
+// DeleteDraft 删除草稿箱中的草稿
+func (s *XiaohongshuService) DeleteDraft(ctx context.Context, req *DraftRequest) (*DraftActionResponse, error) {
+	kind, err := xiaohongshu.ParseDraftKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newDraftBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	if err := xiaohongshu.NewDraftAction(page).DeleteDraft(ctx, kind, *req.Index, req.Title); err != nil {
+		return nil, err
+	}
+	return &DraftActionResponse{Title: req.Title, Success: true, Message: "草稿删除成功"}, nil
+}
+
 // ListFeeds 获取Feeds列表
 func (s *XiaohongshuService) ListFeeds(ctx context.Context) (*FeedsListResponse, error) {
 	b := newBrowser()
@@ -381,6 +695,62 @@ func (s *XiaohongshuService) SearchFeeds(ctx context.Context, keyword string, fi
 	return response, nil
 }
 
+// SearchSuggest 获取搜索框对关键词前缀的联想词
+func (s *XiaohongshuService) SearchSuggest(ctx context.Context, keyword string) (*SearchSuggestResponse, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	action := xiaohongshu.NewSearchSuggestAction(page)
+
+	suggestions, err := action.Suggest(ctx, keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SearchSuggestResponse{
+		Keyword:     keyword,
+		Suggestions: suggestions,
+		Count:       len(suggestions),
+	}, nil
+}
+
+// TrendingKeywords 获取热搜榜
+func (s *XiaohongshuService) TrendingKeywords(ctx context.Context) (*TrendingKeywordsResponse, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	action := xiaohongshu.NewSearchSuggestAction(page)
+
+	keywords, err := action.TrendingKeywords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrendingKeywordsResponse{
+		Keywords: keywords,
+		Count:    len(keywords),
+	}, nil
+}
+
+// TopicFeeds 获取话题页的统计信息和笔记列表
+func (s *XiaohongshuService) TopicFeeds(ctx context.Context, topicID string, limit int) (*xiaohongshu.TopicFeedsResponse, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	action := xiaohongshu.NewTopicAction(page)
+
+	return action.GetTopicFeeds(ctx, topicID, limit)
+}
+
 // GetFeedDetail 获取Feed详情
 func (s *XiaohongshuService) GetFeedDetail(ctx context.Context, feedID, xsecToken string, loadAllComments bool) (*FeedDetailResponse, error) {
 	return s.GetFeedDetailWithConfig(ctx, feedID, xsecToken, loadAllComments, xiaohongshu.DefaultCommentLoadConfig())
@@ -411,6 +781,163 @@ func (s *XiaohongshuService) GetFeedDetailWithConfig(ctx context.Context, feedID
 	return response, nil
 }
 
+// ListComments 分页读取笔记的一级评论
+// 还有下一页时保留页面，带游标的下一次请求从上次滚动到的位置继续
+func (s *XiaohongshuService) ListComments(ctx context.Context, feedID, xsecToken, cursor string, limit int) (*xiaohongshu.CommentPage, error) {
+	session := s.commentSessions.take(feedID, cursor != "")
+
+	result, err := session.action.ListComments(ctx, feedID, xsecToken, cursor, limit)
+	s.commentSessions.put(feedID, session, err == nil && result.HasMore)
+	return result, err
+}
+
+// ListFollowUsers 分页读取当前登录账号的粉丝或关注列表
+// 与 ListComments 一样，还有下一页时保留打开着列表弹窗的页面
+func (s *XiaohongshuService) ListFollowUsers(ctx context.Context, kind xiaohongshu.FollowListKind, cursor string, limit int) (*xiaohongshu.FollowUserPage, error) {
+	session := s.followSessions.take(string(kind), cursor != "")
+
+	var result *xiaohongshu.FollowUserPage
+	var err error
+	if kind == xiaohongshu.FollowListFollowers {
+		result, err = session.action.ListFollowers(ctx, cursor, limit)
+	} else {
+		result, err = session.action.ListFollowings(ctx, cursor, limit)
+	}
+	s.followSessions.put(string(kind), session, err == nil && result.HasMore)
+	return result, err
+}
+
+// CommentThread 展开指定评论的回复并返回回复树
+func (s *XiaohongshuService) CommentThread(ctx context.Context, feedID, xsecToken, commentID string, limit int) (*xiaohongshu.CommentThread, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	action := xiaohongshu.NewFeedDetailAction(page)
+
+	return action.GetCommentThread(ctx, feedID, xsecToken, commentID, limit)
+}
+
+// DownloadFeedMedia 下载笔记图片（视频笔记为封面）到本地图片目录，路径写入 MediaFiles
+// 部分图片下载失败时保留已成功的路径并返回错误
+func (s *XiaohongshuService) DownloadFeedMedia(resp *FeedDetailResponse) error {
+	detail, ok := resp.Data.(*xiaohongshu.FeedDetailResponse)
+	if !ok || detail == nil {
+		return fmt.Errorf("feed %s 没有可下载的媒体数据", resp.FeedID)
+	}
+
+	urls := detail.Note.Media.ImageURLs()
+	if len(urls) == 0 {
+		return nil
+	}
+
+	imageDownloader := downloader.NewImageDownloader(configs.GetImagesPath())
+	paths, err := imageDownloader.DownloadImages(urls)
+	resp.MediaFiles = paths
+	return err
+}
+
+// DigestFeedComments 根据已加载的评论生成评论区摘要，only 为 true 时去掉评论原文以节省篇幅
+func (s *XiaohongshuService) DigestFeedComments(resp *FeedDetailResponse, only bool) {
+	detail, ok := resp.Data.(*xiaohongshu.FeedDetailResponse)
+	if !ok || detail == nil {
+		return
+	}
+
+	resp.CommentDigest = xiaohongshu.DigestComments(detail.Comments.List)
+	if only {
+		detail.Comments.List = nil
+	}
+}
+
+// 图片附件默认限制
+const (
+	defaultAttachMaxImages    = 4
+	defaultAttachMaxDimension = 768
+	maxAttachmentBytes        = 512 * 1024
+)
+
+// AttachFeedDetailImages 为笔记图片（视频笔记为封面）生成缩略图附件
+func (s *XiaohongshuService) AttachFeedDetailImages(resp *FeedDetailResponse, opts ImageAttachOptions) {
+	detail, ok := resp.Data.(*xiaohongshu.FeedDetailResponse)
+	if !ok || detail == nil {
+		return
+	}
+
+	var sources []ImageAttachment
+	for _, img := range detail.Note.ImageList {
+		// 预览图尺寸更小，缩放前优先使用
+		imageURL := img.URLPre
+		if imageURL == "" {
+			imageURL = img.URLDefault
+		}
+		if imageURL != "" {
+			sources = append(sources, ImageAttachment{FeedID: resp.FeedID, SourceURL: imageURL})
+		}
+	}
+
+	resp.Images = buildImageAttachments(sources, opts)
+}
+
+// AttachFeedCovers 为 Feed 列表生成封面缩略图附件
+func (s *XiaohongshuService) AttachFeedCovers(resp *FeedsListResponse, opts ImageAttachOptions) {
+	var sources []ImageAttachment
+	for _, feed := range resp.Feeds {
+		cover := feed.NoteCard.Cover
+		imageURL := cover.URLPre
+		if imageURL == "" {
+			imageURL = cover.URLDefault
+		}
+		if imageURL == "" {
+			imageURL = cover.URL
+		}
+		if imageURL != "" {
+			sources = append(sources, ImageAttachment{FeedID: feed.ID, SourceURL: imageURL})
+		}
+	}
+
+	resp.Images = buildImageAttachments(sources, opts)
+}
+
+// buildImageAttachments 下载图片并生成缩略图，单张失败时跳过
+func buildImageAttachments(sources []ImageAttachment, opts ImageAttachOptions) []ImageAttachment {
+	if opts.MaxImages <= 0 {
+		opts.MaxImages = defaultAttachMaxImages
+	}
+	if opts.MaxDimension <= 0 {
+		opts.MaxDimension = defaultAttachMaxDimension
+	}
+
+	imageDownloader := downloader.NewImageDownloader(configs.GetImagesPath())
+
+	var attachments []ImageAttachment
+	for _, src := range sources {
+		if len(attachments) >= opts.MaxImages {
+			break
+		}
+
+		data, mimeType, err := imageDownloader.FetchImage(src.SourceURL)
+		if err != nil {
+			logrus.Warnf("下载图片失败，跳过: %s %v", src.SourceURL, err)
+			continue
+		}
+
+		thumb, thumbMime, err := downloader.MakeThumbnail(data, mimeType, opts.MaxDimension, maxAttachmentBytes)
+		if err != nil {
+			logrus.Warnf("生成缩略图失败，跳过: %s %v", src.SourceURL, err)
+			continue
+		}
+
+		src.MimeType = thumbMime
+		src.Data = base64.StdEncoding.EncodeToString(thumb)
+		attachments = append(attachments, src)
+	}
+
+	return attachments
+}
+
 // UserProfile 获取用户信息
 func (s *XiaohongshuService) UserProfile(ctx context.Context, userID, xsecToken string) (*UserProfileResponse, error) {
 	b := newBrowser()
@@ -436,7 +963,12 @@ func (s *XiaohongshuService) UserProfile(ctx context.Context, userID, xsecToken
 }
 
 // PostCommentToFeed 发表评论到Feed
-func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, feedID, xsecToken, content string) (*PostCommentResponse, error) {
+func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, req *PostCommentRequest) (*PostCommentResponse, error) {
+	content, err := s.commentContent(req.Content, req.Mentions, req.Image)
+	if err != nil {
+		return nil, err
+	}
+
 	b := newBrowser()
 	defer b.Close()
 
@@ -445,11 +977,19 @@ func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, feedID, xsec
 
 	action := xiaohongshu.NewCommentFeedAction(page)
 
-	if err := action.PostComment(ctx, feedID, xsecToken, content); err != nil {
+	posted, err := action.PostComment(ctx, req.FeedID, req.XsecToken, content)
+	if err != nil {
 		return nil, err
 	}
 
-	return &PostCommentResponse{FeedID: feedID, Success: true, Message: "评论发表成功"}, nil
+	return &PostCommentResponse{
+		FeedID:     req.FeedID,
+		CommentID:  posted.ID,
+		CreateTime: posted.CreateTime,
+		Mentions:   posted.Mentions,
+		Success:    true,
+		Message:    "评论发表成功",
+	}, nil
 }
 
 // LikeFeed 点赞笔记
@@ -513,7 +1053,12 @@ func (s *XiaohongshuService) UnfavoriteFeed(ctx context.Context, feedID, xsecTok
 }
 
 // ReplyCommentToFeed 回复指定评论
-func (s *XiaohongshuService) ReplyCommentToFeed(ctx context.Context, feedID, xsecToken, commentID, userID, content string) (*ReplyCommentResponse, error) {
+func (s *XiaohongshuService) ReplyCommentToFeed(ctx context.Context, req *ReplyCommentRequest) (*ReplyCommentResponse, error) {
+	content, err := s.commentContent(req.Content, req.Mentions, req.Image)
+	if err != nil {
+		return nil, err
+	}
+
 	b := newBrowser()
 	defer b.Close()
 
@@ -522,23 +1067,403 @@ func (s *XiaohongshuService) ReplyCommentToFeed(ctx context.Context, feedID, xse
 
 	action := xiaohongshu.NewCommentFeedAction(page)
 
-	if err := action.ReplyToComment(ctx, feedID, xsecToken, commentID, userID, content); err != nil {
+	posted, err := action.ReplyToComment(ctx, req.FeedID, req.XsecToken, req.CommentID, req.UserID, content)
+	if err != nil {
 		return nil, err
 	}
 
 	return &ReplyCommentResponse{
-		FeedID:          feedID,
-		TargetCommentID: commentID,
-		TargetUserID:    userID,
+		FeedID:          req.FeedID,
+		TargetCommentID: req.CommentID,
+		TargetUserID:    req.UserID,
+		CommentID:       posted.ID,
+		CreateTime:      posted.CreateTime,
+		Mentions:        posted.Mentions,
 		Success:         true,
 		Message:         "评论回复成功",
 	}, nil
 }
 
+// commentContent 组装评论内容，图片为 URL 时先下载到本地
+func (s *XiaohongshuService) commentContent(text string, mentions []string, image string) (xiaohongshu.CommentContent, error) {
+	content := xiaohongshu.CommentContent{Text: text, Mentions: mentions}
+	if image == "" {
+		return content, nil
+	}
+
+	paths, err := s.processImages([]string{image})
+	if err != nil {
+		return content, fmt.Errorf("处理评论图片失败: %w", err)
+	}
+	content.ImagePath = paths[0]
+	return content, nil
+}
+
+// DeleteComment 删除当前登录账号发表的评论
+func (s *XiaohongshuService) DeleteComment(ctx context.Context, feedID, xsecToken, commentID string) (*DeleteCommentResponse, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	action := xiaohongshu.NewCommentFeedAction(page)
+
+	if err := action.DeleteComment(ctx, feedID, xsecToken, commentID); err != nil {
+		return nil, err
+	}
+
+	return &DeleteCommentResponse{
+		FeedID:    feedID,
+		CommentID: commentID,
+		Success:   true,
+		Message:   "评论删除成功",
+	}, nil
+}
+
+// ListEmojis 获取评论表情面板中的表情名称，结果会缓存一段时间
+func (s *XiaohongshuService) ListEmojis(ctx context.Context) (*EmojiListResponse, error) {
+	if names, ok := xiaohongshu.CachedEmojis(); ok {
+		return &EmojiListResponse{Emojis: names, Count: len(names)}, nil
+	}
+
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	// 表情面板挂在笔记的评论框上，借用首页推荐的第一篇笔记打开
+	feeds, err := xiaohongshu.NewFeedsListAction(page).GetFeedsList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *xiaohongshu.Feed
+	for i := range feeds {
+		if feeds[i].ModelType == "note" && feeds[i].XsecToken != "" {
+			target = &feeds[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("首页推荐中没有可打开的笔记")
+	}
+
+	names, err := xiaohongshu.NewEmojiAction(page).ListEmojis(ctx, target.ID, target.XsecToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmojiListResponse{Emojis: names, Count: len(names)}, nil
+}
+
+// LikeComment 点赞或取消点赞评论，已处于目标状态时不重复点击
+func (s *XiaohongshuService) LikeComment(ctx context.Context, feedID, xsecToken, commentID string, unlike bool) (*LikeCommentResponse, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	action := xiaohongshu.NewCommentLikeAction(page)
+
+	if unlike {
+		if err := action.UnlikeComment(ctx, feedID, xsecToken, commentID); err != nil {
+			return nil, err
+		}
+		return &LikeCommentResponse{FeedID: feedID, CommentID: commentID, Liked: false, Success: true, Message: "取消评论点赞成功或未点赞"}, nil
+	}
+
+	if err := action.LikeComment(ctx, feedID, xsecToken, commentID); err != nil {
+		return nil, err
+	}
+	return &LikeCommentResponse{FeedID: feedID, CommentID: commentID, Liked: true, Success: true, Message: "评论点赞成功或已点赞"}, nil
+}
+
+// FollowUser 关注或取消关注用户，传 feed_id 时在笔记详情页操作作者；已处于目标状态时不重复点击
+func (s *XiaohongshuService) FollowUser(ctx context.Context, req *FollowRequest) (*FollowResponse, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	action := xiaohongshu.NewFollowAction(page)
+	follow := !req.Unfollow
+
+	var (
+		result *xiaohongshu.FollowResult
+		err    error
+	)
+	if req.UserID != "" {
+		result, err = action.FollowUser(ctx, req.UserID, req.XsecToken, follow)
+	} else {
+		result, err = action.FollowNoteAuthor(ctx, req.FeedID, req.XsecToken, follow)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	message := "关注成功"
+	switch {
+	case follow && !result.Changed:
+		message = "已关注，无需重复关注"
+	case !follow && result.Changed:
+		message = "取消关注成功"
+	case !follow:
+		message = "未关注，无需取消"
+	}
+
+	return &FollowResponse{
+		UserID:   req.UserID,
+		FeedID:   req.FeedID,
+		Followed: result.Followed,
+		Changed:  result.Changed,
+		Success:  true,
+		Message:  message,
+	}, nil
+}
+
+// Notifications 读取通知页，只返回晚于 since 的通知
+func (s *XiaohongshuService) Notifications(ctx context.Context, req *NotificationsRequest) (*NotificationsResponse, error) {
+	tabs, err := xiaohongshu.ParseNotificationTabs(req.Tabs)
+	if err != nil {
+		return nil, err
+	}
+	since, err := xhsutil.ParseSince(req.Since, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	list, err := xiaohongshu.NewNotificationAction(page).Notifications(ctx, tabs, since, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &NotificationsResponse{Notifications: list, Count: len(list)}
+	if !since.IsZero() {
+		resp.Since = since.Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+// CollectCreatorStats 采集一次创作中心数据，并追加到本地数据快照中
+func (s *XiaohongshuService) CollectCreatorStats(ctx context.Context) (*xiaohongshu.CreatorStats, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	stats, err := xiaohongshu.NewCreatorStatsAction(page).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.creatorStats.Append(*stats); err != nil {
+		return nil, fmt.Errorf("保存数据快照失败: %w", err)
+	}
+	return stats, nil
+}
+
+// CreatorStatsTrend 根据本地数据快照计算 since 以来的变化，不打开浏览器
+func (s *XiaohongshuService) CreatorStatsTrend(since string) (*creatorstats.Trend, error) {
+	t, err := xhsutil.ParseSince(since, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return s.creatorStats.Trend(t)
+}
+
+// ListMyNotes 返回当前账号最近发布的笔记及其数据
+func (s *XiaohongshuService) ListMyNotes(ctx context.Context, limit int) (*MyNotesResponse, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	notes, err := xiaohongshu.NewMyNotesAction(page).ListMyNotes(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &MyNotesResponse{Notes: notes, Count: len(notes)}, nil
+}
+
+// DeleteNote 删除当前账号发布的笔记
+func (s *XiaohongshuService) DeleteNote(ctx context.Context, noteID string) (*NoteManageResponse, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	if err := xiaohongshu.NewMyNotesAction(page).DeleteNote(ctx, noteID); err != nil {
+		return nil, err
+	}
+	return &NoteManageResponse{NoteID: noteID, Changed: true, Success: true, Message: "笔记删除成功"}, nil
+}
+
+// SetNoteVisibility 把当前账号的笔记设为仅自己可见或公开
+func (s *XiaohongshuService) SetNoteVisibility(ctx context.Context, noteID string, private bool) (*NoteManageResponse, error) {
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	changed, err := xiaohongshu.NewMyNotesAction(page).SetNoteVisibility(ctx, noteID, private)
+	if err != nil {
+		return nil, err
+	}
+
+	message := "已设为公开可见"
+	if private {
+		message = "已设为仅自己可见"
+	}
+	if !changed {
+		message += "，无需修改"
+	}
+	return &NoteManageResponse{NoteID: noteID, Private: &private, Changed: changed, Success: true, Message: message}, nil
+}
+
+// ListConversations 返回私信会话列表，允许名单模式下只返回名单中的会话
+func (s *XiaohongshuService) ListConversations(ctx context.Context) (*ConversationListResponse, error) {
+	if !configs.MessagesEnabled() {
+		return nil, xhserrors.ErrMessagesDisabled
+	}
+
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	list, err := xiaohongshu.NewMessageAction(page).ListConversations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conversations := make([]xiaohongshu.Conversation, 0, len(list))
+	for _, c := range list {
+		if configs.MessageRecipientAllowed(c.UserID) {
+			conversations = append(conversations, c)
+		}
+	}
+
+	return &ConversationListResponse{
+		Conversations: conversations,
+		Count:         len(conversations),
+		AllowlistMode: configs.MessageAllowlistMode(),
+	}, nil
+}
+
+// ReadConversation 读取与指定用户的私信会话
+func (s *XiaohongshuService) ReadConversation(ctx context.Context, userID string, limit int) (*xiaohongshu.ConversationMessages, error) {
+	if err := checkMessageRecipient(userID); err != nil {
+		return nil, err
+	}
+
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	return xiaohongshu.NewMessageAction(page).ReadConversation(ctx, userID, limit)
+}
+
+// SendMessage 在已有会话中给指定用户发送文字私信
+func (s *XiaohongshuService) SendMessage(ctx context.Context, userID, content string) (*SendMessageResponse, error) {
+	if err := checkMessageRecipient(userID); err != nil {
+		return nil, err
+	}
+
+	b := newBrowser()
+	defer b.Close()
+
+	page := b.NewPage()
+	defer page.Close()
+
+	if err := xiaohongshu.NewMessageAction(page).SendMessage(ctx, userID, content); err != nil {
+		return nil, err
+	}
+	return &SendMessageResponse{UserID: userID, Success: true, Message: "私信发送成功"}, nil
+}
+
+// checkMessageRecipient 私信功能须显式开启，允许名单模式下只能与名单中的用户收发私信
+func checkMessageRecipient(userID string) error {
+	if !configs.MessagesEnabled() {
+		return xhserrors.ErrMessagesDisabled
+	}
+	if !configs.MessageRecipientAllowed(userID) {
+		return xhserrors.ErrMessageRecipientNotAllowed
+	}
+	return nil
+}
+
 func newBrowser() *headless_browser.Browser {
 	return browser.NewBrowser(configs.IsHeadless(), browser.WithBinPath(configs.GetBinPath()))
 }
 
+// draftBrowserMu 草稿浏览器共用一个配置目录，同一时间只能启动一个
+var draftBrowserMu sync.Mutex
+
+// draftBrowser 关闭时释放 draftBrowserMu
+type draftBrowser struct {
+	*headless_browser.Browser
+}
+
+func (b draftBrowser) Close() {
+	defer draftBrowserMu.Unlock()
+	b.Browser.Close()
+}
+
+// browserCloser 发布流程使用的浏览器，普通浏览器或草稿浏览器
+type browserCloser interface {
+	NewPage() *rod.Page
+	Close()
+}
+
+// newDraftBrowser 使用固定配置目录启动浏览器，创作中心网页端的草稿保存在浏览器本地
+func newDraftBrowser() draftBrowser {
+	dir, err := filepath.Abs(configs.GetDraftProfilePath())
+	if err != nil {
+		dir = configs.GetDraftProfilePath()
+	}
+
+	draftBrowserMu.Lock()
+	launched := false
+	defer func() {
+		// 启动浏览器 panic 时释放锁，避免之后的草稿操作全部阻塞
+		if !launched {
+			draftBrowserMu.Unlock()
+		}
+	}()
+
+	b := browser.NewBrowser(configs.IsHeadless(),
+		browser.WithBinPath(configs.GetBinPath()),
+		browser.WithUserDataDir(dir),
+	)
+	launched = true
+	return draftBrowser{b}
+}
+
+// newPublishBrowser 存草稿时使用草稿浏览器，否则使用普通浏览器
+func newPublishBrowser(draft bool) browserCloser {
+	if draft {
+		return newDraftBrowser()
+	}
+	return newBrowser()
+}
+
 func saveCookies(page *rod.Page) error {
 	cks, err := page.Browser().GetCookies()
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 type Browser struct {
 	browser  *rod.Browser
 	launcher *launcher.Launcher
+	keepData bool
 }
 
 type Config struct {
@@ -20,6 +21,7 @@ type Config struct {
 	UserAgent     string
 	Cookies       string
 	ChromeBinPath string
+	UserDataDir   string
 	Trace         bool
 }
 
@@ -51,6 +53,12 @@ func WithChromeBinPath(path string) Option {
 	return func(c *Config) { c.ChromeBinPath = path }
 }
 
+// WithUserDataDir uses a persistent profile directory that is kept on Close,
+// so local storage such as drafts survives between browser sessions.
+func WithUserDataDir(dir string) Option {
+	return func(c *Config) { c.UserDataDir = dir }
+}
+
 func WithTrace() Option {
 	return func(c *Config) { c.Trace = true }
 }
@@ -71,6 +79,9 @@ func New(options ...Option) *Browser {
 	if cfg.ChromeBinPath != "" {
 		l = l.Bin(cfg.ChromeBinPath)
 	}
+	if cfg.UserDataDir != "" {
+		l = l.UserDataDir(cfg.UserDataDir)
+	}
 
 	url := l.MustLaunch()
 
@@ -91,11 +102,16 @@ func New(options ...Option) *Browser {
 	return &Browser{
 		browser:  browser,
 		launcher: l,
+		keepData: cfg.UserDataDir != "",
 	}
 }
 
 func (b *Browser) Close() {
 	b.browser.MustClose()
+	if b.keepData {
+		// Cleanup would remove the persistent profile directory
+		return
+	}
 	b.launcher.Cleanup()
 }
 
@@ -52,6 +52,32 @@ type FeedDetailRequest struct {
 	XsecToken       string             `json:"xsec_token" binding:"required"`
 	LoadAllComments bool               `json:"load_all_comments,omitempty"`
 	CommentConfig   *CommentLoadConfig `json:"comment_config,omitempty"`
+	// 是否将笔记图片（视频笔记为封面）下载到本地图片目录
+	DownloadMedia bool `json:"download_media,omitempty"`
+	// 是否附带缩略图（Base64），供多模态模型查看
+	AttachImages      bool `json:"attach_images,omitempty"`
+	MaxImages         int  `json:"max_images,omitempty"`
+	MaxImageDimension int  `json:"max_image_dimension,omitempty"`
+	// 是否附带评论区摘要；DigestOnly 为 true 时只返回摘要，不返回评论原文
+	CommentDigest bool `json:"comment_digest,omitempty"`
+	DigestOnly    bool `json:"digest_only,omitempty"`
+}
+
+// ListCommentsRequest 分页读取评论请求
+type ListCommentsRequest struct {
+	FeedID    string `json:"feed_id" binding:"required"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+	// 上一页返回的 cursor，为空表示第一页
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// CommentThreadRequest 读取评论回复请求
+type CommentThreadRequest struct {
+	FeedID    string `json:"feed_id" binding:"required"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+	CommentID string `json:"comment_id" binding:"required"`
+	Limit     int    `json:"limit,omitempty"`
 }
 
 type SearchFeedsRequest struct {
@@ -61,40 +87,163 @@ type SearchFeedsRequest struct {
 
 // FeedDetailResponse Feed详情响应
 type FeedDetailResponse struct {
-	FeedID string `json:"feed_id"`
-	Data   any    `json:"data"`
+	FeedID        string                     `json:"feed_id"`
+	Data          any                        `json:"data"`
+	MediaFiles    []string                   `json:"media_files,omitempty"`    // 已下载到本地的图片路径
+	Images        []ImageAttachment          `json:"images,omitempty"`         // 缩略图附件
+	CommentDigest *xiaohongshu.CommentDigest `json:"comment_digest,omitempty"` // 评论区摘要
+}
+
+// ImageAttachOptions 图片附件的数量和尺寸限制，0 表示使用默认值
+type ImageAttachOptions struct {
+	MaxImages    int
+	MaxDimension int
+}
+
+// ImageAttachment 附带给多模态模型的缩略图
+type ImageAttachment struct {
+	FeedID    string `json:"feed_id,omitempty"`
+	SourceURL string `json:"source_url"`
+	MimeType  string `json:"mime_type"`
+	Data      string `json:"data"` // Base64 编码的图片数据
 }
 
 // PostCommentRequest 发表评论请求
 type PostCommentRequest struct {
-	FeedID    string `json:"feed_id" binding:"required"`
-	XsecToken string `json:"xsec_token" binding:"required"`
-	Content   string `json:"content" binding:"required"`
+	FeedID    string   `json:"feed_id" binding:"required"`
+	XsecToken string   `json:"xsec_token" binding:"required"`
+	Content   string   `json:"content" binding:"required"`
+	Mentions  []string `json:"mentions,omitempty"` // 要 @ 的用户 ID 或昵称
+	Image     string   `json:"image,omitempty"`    // 附带一张图片，HTTP/HTTPS 链接或本地绝对路径
 }
 
 // PostCommentResponse 发表评论响应
 type PostCommentResponse struct {
-	FeedID  string `json:"feed_id"`
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	FeedID     string                    `json:"feed_id"`
+	CommentID  string                    `json:"comment_id"`            // 新评论 ID
+	CreateTime int64                     `json:"create_time,omitempty"` // 新评论创建时间（毫秒）
+	Mentions   []xiaohongshu.MentionUser `json:"mentions,omitempty"`    // 已插入的提及
+	Success    bool                      `json:"success"`
+	Message    string                    `json:"message"`
 }
 
 // ReplyCommentRequest 回复评论请求
 type ReplyCommentRequest struct {
-	FeedID    string `json:"feed_id" binding:"required"`
-	XsecToken string `json:"xsec_token" binding:"required"`
-	CommentID string `json:"comment_id" binding:"required_without=UserID"`
-	UserID    string `json:"user_id" binding:"required_without=CommentID"`
-	Content   string `json:"content" binding:"required"`
+	FeedID    string   `json:"feed_id" binding:"required"`
+	XsecToken string   `json:"xsec_token" binding:"required"`
+	CommentID string   `json:"comment_id" binding:"required_without=UserID"`
+	UserID    string   `json:"user_id" binding:"required_without=CommentID"`
+	Content   string   `json:"content" binding:"required"`
+	Mentions  []string `json:"mentions,omitempty"` // 要 @ 的用户 ID 或昵称
+	Image     string   `json:"image,omitempty"`    // 附带一张图片，HTTP/HTTPS 链接或本地绝对路径
 }
 
 // ReplyCommentResponse 回复评论响应
 type ReplyCommentResponse struct {
-	FeedID          string `json:"feed_id"`
-	TargetCommentID string `json:"target_comment_id,omitempty"`
-	TargetUserID    string `json:"target_user_id,omitempty"`
-	Success         bool   `json:"success"`
-	Message         string `json:"message"`
+	FeedID          string                    `json:"feed_id"`
+	TargetCommentID string                    `json:"target_comment_id,omitempty"`
+	TargetUserID    string                    `json:"target_user_id,omitempty"`
+	CommentID       string                    `json:"comment_id"`            // 新回复 ID
+	CreateTime      int64                     `json:"create_time,omitempty"` // 新回复创建时间（毫秒）
+	Mentions        []xiaohongshu.MentionUser `json:"mentions,omitempty"`    // 已插入的提及
+	Success         bool                      `json:"success"`
+	Message         string                    `json:"message"`
+}
+
+// DeleteCommentRequest 删除评论请求
+type DeleteCommentRequest struct {
+	FeedID    string `json:"feed_id" binding:"required"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+	CommentID string `json:"comment_id" binding:"required"`
+}
+
+// DeleteCommentResponse 删除评论响应
+type DeleteCommentResponse struct {
+	FeedID    string `json:"feed_id"`
+	CommentID string `json:"comment_id"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+}
+
+// LikeCommentRequest 评论点赞/取消点赞请求
+type LikeCommentRequest struct {
+	FeedID    string `json:"feed_id" binding:"required"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+	CommentID string `json:"comment_id" binding:"required"`
+	Unlike    bool   `json:"unlike,omitempty"`
+}
+
+// LikeCommentResponse 评论点赞/取消点赞响应
+type LikeCommentResponse struct {
+	FeedID    string `json:"feed_id"`
+	CommentID string `json:"comment_id"`
+	Liked     bool   `json:"liked"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+}
+
+// FollowRequest 关注/取消关注请求，user_id 与 feed_id 二选一；传 feed_id 时关注该笔记的作者
+type FollowRequest struct {
+	UserID    string `json:"user_id,omitempty" binding:"required_without=FeedID"`
+	FeedID    string `json:"feed_id,omitempty"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+	Unfollow  bool   `json:"unfollow,omitempty"`
+}
+
+// FollowResponse 关注/取消关注响应
+type FollowResponse struct {
+	UserID   string `json:"user_id,omitempty"`
+	FeedID   string `json:"feed_id,omitempty"`
+	Followed bool   `json:"followed"`
+	Changed  bool   `json:"changed"`
+	Success  bool   `json:"success"`
+	Message  string `json:"message"`
+}
+
+// DraftRequest 发布或删除草稿请求，index 和 title 来自草稿列表，用于核对草稿
+type DraftRequest struct {
+	Kind  string `json:"kind,omitempty"` // image 或 video，默认 image
+	Index *int   `json:"index" binding:"required,min=0"`
+	Title string `json:"title" binding:"required"`
+}
+
+// DeleteNoteRequest 删除笔记请求
+type DeleteNoteRequest struct {
+	NoteID string `json:"note_id" binding:"required"`
+}
+
+// NoteVisibilityRequest 修改笔记可见范围请求
+type NoteVisibilityRequest struct {
+	NoteID  string `json:"note_id" binding:"required"`
+	Private bool   `json:"private"` // true 为仅自己可见，false 为公开
+}
+
+// ReadConversationRequest 读取私信会话请求
+type ReadConversationRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// SendMessageRequest 发送私信请求
+type SendMessageRequest struct {
+	UserID  string `json:"user_id" binding:"required"`
+	Content string `json:"content" binding:"required"`
+}
+
+// NotificationsRequest 通知请求
+type NotificationsRequest struct {
+	// 要读取的标签：comments（评论和@）、likes（赞和收藏）、follows（新增关注），为空表示全部
+	Tabs []string `json:"tabs,omitempty"`
+	// 只返回晚于该时间的通知：RFC3339、2006-01-02、Unix 秒或 24h 这样的时长，为空不过滤
+	Since string `json:"since,omitempty"`
+	// 每个标签最多返回的条数，默认30，最多100
+	Limit int `json:"limit,omitempty"`
+}
+
+// TopicFeedsRequest 话题页请求
+type TopicFeedsRequest struct {
+	TopicID string `json:"topic_id" binding:"required"`
+	Limit   int    `json:"limit,omitempty"`
 }
 
 // UserProfileRequest 用户主页请求
@@ -103,6 +252,17 @@ type UserProfileRequest struct {
 	XsecToken string `json:"xsec_token" binding:"required"`
 }
 
+// ScheduledPublishRequest 取消本地定时发布任务请求
+type ScheduledPublishRequest struct {
+	JobID string `json:"job_id" binding:"required"`
+}
+
+// RescheduleRequest 修改本地定时发布时间请求
+type RescheduleRequest struct {
+	JobID      string `json:"job_id" binding:"required"`
+	ScheduleAt string `json:"schedule_at" binding:"required"` // ISO8601格式，可以是任意未来时间
+}
+
 // ActionResult 通用动作响应（点赞/收藏等）
 type ActionResult struct {
 	FeedID  string `json:"feed_id"`
@@ -0,0 +1,204 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// deletedCommentTimeout 确认删除后等待评论从评论区消失的最长时间
+const deletedCommentTimeout = 8 * time.Second
+
+const (
+	// selectorCommentMenu 评论"更多"按钮打开的操作菜单
+	selectorCommentMenu = ".dropdown, .menu, .operation-list, .more-menu"
+	// selectorPopover 挂在 body 下的浮层，菜单不在评论元素内时从这里找
+	selectorPopover = ".dropdown-container, .reds-popover, .popover, [class*='popover']"
+	// selectorConfirmDialog 删除确认弹窗
+	selectorConfirmDialog = ".reds-modal, .modal, .dialog, [role='dialog']"
+)
+
+// DeleteComment 删除当前登录账号发表的评论或回复（悬停菜单 → 删除 → 确认）
+// 评论作者不是当前登录账号时返回 errors.ErrNotOwnComment，不会进行任何操作
+func (f *CommentFeedAction) DeleteComment(ctx context.Context, feedID, xsecToken, commentID string) error {
+	// 不使用 Context(ctx)，避免继承外部 context 的超时
+	page := f.page.Timeout(5 * time.Minute)
+	url := makeFeedDetailURL(feedID, xsecToken)
+	logrus.Infof("打开 feed 详情页删除评论: %s, commentID=%s", url, commentID)
+
+	if err := openFeedDetailPage(page, url); err != nil {
+		return err
+	}
+
+	commentEl, err := findCommentElement(page, commentID, "")
+	if err != nil {
+		return fmt.Errorf("无法找到评论: %w", err)
+	}
+
+	me, err := currentUserID(page)
+	if err != nil {
+		return err
+	}
+	author, err := commentAuthorID(page, feedID, commentID)
+	if err != nil {
+		return err
+	}
+	if author != me {
+		logrus.Warnf("拒绝删除评论 %s: 作者 %s 不是当前账号 %s", commentID, author, me)
+		return errors.ErrNotOwnComment
+	}
+
+	commentEl.MustScrollIntoView()
+	sleepRandom(humanDelayRange.min, humanDelayRange.max)
+
+	if err := commentEl.Hover(); err != nil {
+		return fmt.Errorf("悬停评论失败: %w", err)
+	}
+	sleepRandom(hoverTimeRange.min, hoverTimeRange.max)
+
+	// 自己的评论悬停后才会出现"更多"按钮
+	moreBtn, err := commentEl.Timeout(3 * time.Second).Element(".right .interactions .more, .right .interactions .operation, .more-operation")
+	if err != nil {
+		return fmt.Errorf("未找到评论的更多操作按钮: %w", err)
+	}
+	if err := moreBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击更多操作按钮失败: %w", err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	menu, err := openedCommentMenu(page, commentEl)
+	if err != nil {
+		return err
+	}
+	deleteItem, err := menu.Timeout(3*time.Second).ElementR(".menu-item, .dropdown-item, .operation-item, li, span", `^\s*删除\s*$`)
+	if err != nil {
+		return fmt.Errorf("未找到删除选项: %w", err)
+	}
+	if err := deleteItem.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击删除选项失败: %w", err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	dialog, err := lastVisible(page, selectorConfirmDialog)
+	if err != nil {
+		return fmt.Errorf("未找到删除确认弹窗: %w", err)
+	}
+	confirmBtn, err := dialog.Timeout(3*time.Second).ElementR("button, .confirm-btn", `^\s*(确定|确认|删除)\s*$`)
+	if err != nil {
+		return fmt.Errorf("未找到删除确认按钮: %w", err)
+	}
+	if err := confirmBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击删除确认按钮失败: %w", err)
+	}
+
+	if !waitCommentGone(page, feedID, commentID) {
+		return fmt.Errorf("删除后评论 %s 仍在评论区，删除可能未成功", commentID)
+	}
+
+	logrus.Infof("删除评论成功: %s", commentID)
+	return nil
+}
+
+// openedCommentMenu 返回目标评论上刚打开的操作菜单
+// 菜单优先在评论元素内查找；浮层挂在 body 下时取最后出现的可见浮层，即刚打开的那个
+func openedCommentMenu(page *rod.Page, commentEl *rod.Element) (*rod.Element, error) {
+	if menu, err := commentEl.Timeout(time.Second).Element(selectorCommentMenu); err == nil {
+		if visible, _ := menu.Visible(); visible {
+			return menu, nil
+		}
+	}
+	menu, err := lastVisible(page, selectorPopover)
+	if err != nil {
+		return nil, fmt.Errorf("未找到评论的操作菜单: %w", err)
+	}
+	return menu, nil
+}
+
+// lastVisible 在 3 秒内等待并返回最后一个可见的 selector 元素
+func lastVisible(page *rod.Page, selector string) (*rod.Element, error) {
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		els, err := page.Elements(selector)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(els) - 1; i >= 0; i-- {
+			if visible, _ := els[i].Visible(); visible {
+				return els[i], nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("没有可见的 %s", selector)
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+}
+
+// currentUserID 读取当前登录账号的用户 ID
+func currentUserID(page *rod.Page) (string, error) {
+	userID := page.MustEval(`() => {
+		const state = window.__INITIAL_STATE__;
+		const info = state && state.user && state.user.userInfo;
+		if (info) {
+			const value = info.value || info._value || info._rawValue || info;
+			if (value && value.userId) {
+				return value.userId;
+			}
+		}
+
+		// 侧边栏 "我" 的链接形如 /user/profile/<userId>
+		const link = document.querySelector('li.user.side-bar-component a.link-wrapper');
+		const match = link && (link.getAttribute('href') || "").match(/\/user\/profile\/([0-9a-zA-Z]+)/);
+		return match ? match[1] : "";
+	}`).String()
+
+	if userID == "" {
+		return "", fmt.Errorf("无法获取当前登录账号，请确认已登录")
+	}
+	return userID, nil
+}
+
+// commentAuthorID 从页面数据中读取评论（含回复）的作者 ID
+func commentAuthorID(page *rod.Page, feedID, commentID string) (string, error) {
+	comments, err := extractCommentList(page, feedID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range comments.List {
+		if c.ID == commentID {
+			return c.UserInfo.UserID, nil
+		}
+		for _, sub := range c.SubComments {
+			if sub.ID == commentID {
+				return sub.UserInfo.UserID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("未在页面数据中找到评论 %s 的作者", commentID)
+}
+
+// waitCommentGone 等待评论从 state 和 DOM 中消失
+func waitCommentGone(page *rod.Page, feedID, commentID string) bool {
+	deadline := time.Now().Add(deletedCommentTimeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(1 * time.Second)
+
+		gone := true
+		for _, c := range collectComments(page, feedID) {
+			if c.ID == commentID {
+				gone = false
+				break
+			}
+		}
+		if gone {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package xiaohongshu
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 摘要各部分的条数上限
+const (
+	digestTopComments = 5
+	digestKeywords    = 10
+	digestQuestions   = 10
+	digestLocations   = 10
+)
+
+// CommentDigest 评论区摘要，在本地根据已加载的评论（含回复）计算
+type CommentDigest struct {
+	CommentCount int             `json:"commentCount"` // 参与统计的评论数（含回复）
+	TopComments  []DigestComment `json:"topComments"`  // 点赞最多的评论
+	Languages    []DigestCount   `json:"languages"`    // 评论语言分布：zh/en/ja/ko/other
+	Keywords     []DigestCount   `json:"keywords"`     // 高频关键词，按出现该词的评论数计
+	Questions    []DigestComment `json:"questions"`    // 评论者提出的问题，按点赞数排序
+	IPLocations  []DigestCount   `json:"ipLocations"`  // IP 属地分布
+}
+
+// DigestComment 摘要中引用的一条评论
+type DigestComment struct {
+	ID        string `json:"id"`
+	Nickname  string `json:"nickname"`
+	Content   string `json:"content"`
+	LikeCount int64  `json:"likeCount"`
+}
+
+// DigestCount 名称及其出现次数
+type DigestCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+var (
+	// digestNoisePattern 统计前去掉的表情、@提及和话题标签
+	digestNoisePattern = regexp.MustCompile(`\[[^\[\]]{1,10}\]|@\S+|#[^#\s]+#?`)
+
+	// questionMarkers 出现即视为提问的词
+	questionMarkers = []string{"?", "？", "吗", "怎么", "什么", "哪", "多少", "为什么", "是不是", "有没有", "求链接", "求教程"}
+
+	// digestStopWords 没有信息量的常见词
+	digestStopWords = map[string]bool{
+		"这个": true, "那个": true, "我们": true, "你们": true, "他们": true, "自己": true,
+		"什么": true, "怎么": true, "就是": true, "还是": true, "可以": true, "没有": true,
+		"一个": true, "真的": true, "哈哈": true, "不是": true, "但是": true, "因为": true,
+		"所以": true, "已经": true, "现在": true, "觉得": true, "我也": true, "也是": true,
+		"the": true, "and": true, "you": true, "for": true, "this": true, "that": true,
+		"is": true, "so": true, "it": true, "to": true, "of": true, "in": true,
+	}
+)
+
+// DigestComments 汇总评论列表：高赞评论、语言分布、高频关键词、提问和 IP 属地分布
+func DigestComments(list []Comment) *CommentDigest {
+	all := flattenComments(list)
+
+	digest := &CommentDigest{CommentCount: len(all)}
+
+	languages := make(map[string]int)
+	keywords := make(map[string]int)
+	locations := make(map[string]int)
+	var questions []Comment
+
+	for _, c := range all {
+		text := strings.TrimSpace(digestNoisePattern.ReplaceAllString(c.Content, " "))
+
+		languages[detectLanguage(text)]++
+		for word := range extractKeywords(text) {
+			keywords[word]++
+		}
+		if c.IPLocation != "" {
+			locations[c.IPLocation]++
+		}
+		if isQuestion(text) {
+			questions = append(questions, c)
+		}
+	}
+
+	digest.TopComments = topDigestComments(all, digestTopComments)
+	digest.Questions = topDigestComments(questions, digestQuestions)
+	digest.Languages = sortedCounts(languages, 1, 0)
+	digest.Keywords = sortedCounts(keywords, 2, digestKeywords)
+	digest.IPLocations = sortedCounts(locations, 1, digestLocations)
+	return digest
+}
+
+// flattenComments 把一级评论和已加载的回复展开为一个列表
+func flattenComments(list []Comment) []Comment {
+	var all []Comment
+	for _, c := range list {
+		all = append(all, c)
+		all = append(all, flattenComments(c.SubComments)...)
+	}
+	return all
+}
+
+// topDigestComments 按点赞数从高到低取前 n 条
+func topDigestComments(comments []Comment, n int) []DigestComment {
+	sorted := make([]Comment, len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].LikeCountNum > sorted[j].LikeCountNum })
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+
+	out := make([]DigestComment, 0, len(sorted))
+	for _, c := range sorted {
+		out = append(out, DigestComment{
+			ID:        c.ID,
+			Nickname:  c.UserInfo.Nickname,
+			Content:   c.Content,
+			LikeCount: c.LikeCountNum,
+		})
+	}
+	return out
+}
+
+// detectLanguage 按文字种类粗略判断语言；假名优先于汉字，避免日文被判为中文
+func detectLanguage(text string) string {
+	var han, kana, hangul, latin int
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	switch {
+	case kana > 0:
+		return "ja"
+	case hangul > 0:
+		return "ko"
+	case han > 0:
+		return "zh"
+	case latin > 0:
+		return "en"
+	default:
+		return "other"
+	}
+}
+
+// extractKeywords 提取一条评论中的候选关键词：汉字取相邻两字，拉丁字母取整词
+func extractKeywords(text string) map[string]bool {
+	words := make(map[string]bool)
+
+	var han []rune
+	var latin []rune
+	flushHan := func() {
+		for i := 0; i+1 < len(han); i++ {
+			if w := string(han[i : i+2]); !digestStopWords[w] {
+				words[w] = true
+			}
+		}
+		han = han[:0]
+	}
+	flushLatin := func() {
+		if w := strings.ToLower(string(latin)); len(latin) >= 2 && !digestStopWords[w] {
+			words[w] = true
+		}
+		latin = latin[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushLatin()
+			han = append(han, r)
+		case unicode.Is(unicode.Latin, r):
+			flushHan()
+			latin = append(latin, r)
+		default:
+			flushHan()
+			flushLatin()
+		}
+	}
+	flushHan()
+	flushLatin()
+	return words
+}
+
+func isQuestion(text string) bool {
+	for _, marker := range questionMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedCounts 按次数从高到低排序，次数相同按名称排序；min 为最低次数，limit 为 0 表示不限条数
+func sortedCounts(counts map[string]int, min, limit int) []DigestCount {
+	out := make([]DigestCount, 0, len(counts))
+	for name, count := range counts {
+		if count >= min {
+			out = append(out, DigestCount{Name: name, Count: count})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func digestTestComment(id, nickname, content, ip string, likes int64, subs ...Comment) Comment {
+	return Comment{
+		ID:           id,
+		Content:      content,
+		IPLocation:   ip,
+		LikeCountNum: likes,
+		UserInfo:     User{Nickname: nickname},
+		SubComments:  subs,
+	}
+}
+
+func TestDigestComments(t *testing.T) {
+	list := []Comment{
+		digestTestComment("c1", "小红", "这个奶茶好好喝[笑哭R]", "上海", 120,
+			digestTestComment("r1", "小蓝", "奶茶在哪里买的？", "北京", 3),
+		),
+		digestTestComment("c2", "Tom", "Looks so good", "美国", 8),
+		digestTestComment("c3", "花子", "美味しそう", "日本", 1),
+		digestTestComment("c4", "小绿", "奶茶多少钱 @小红", "上海", 50),
+	}
+
+	digest := DigestComments(list)
+	require.NotNil(t, digest)
+
+	assert.Equal(t, 5, digest.CommentCount)
+
+	require.Len(t, digest.TopComments, 5)
+	assert.Equal(t, "c1", digest.TopComments[0].ID)
+	assert.Equal(t, "c4", digest.TopComments[1].ID)
+
+	assert.Equal(t, []DigestCount{{Name: "zh", Count: 3}, {Name: "en", Count: 1}, {Name: "ja", Count: 1}}, digest.Languages)
+
+	require.NotEmpty(t, digest.Keywords)
+	assert.Equal(t, DigestCount{Name: "奶茶", Count: 3}, digest.Keywords[0])
+
+	require.Len(t, digest.Questions, 2)
+	assert.Equal(t, "c4", digest.Questions[0].ID)
+	assert.Equal(t, "r1", digest.Questions[1].ID)
+
+	assert.Equal(t, DigestCount{Name: "上海", Count: 2}, digest.IPLocations[0])
+	assert.Len(t, digest.IPLocations, 4)
+}
+
+func TestDigestCommentsEmpty(t *testing.T) {
+	digest := DigestComments(nil)
+	assert.Equal(t, 0, digest.CommentCount)
+	assert.Empty(t, digest.TopComments)
+	assert.Empty(t, digest.Keywords)
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "好看", want: "zh"},
+		{text: "so cute", want: "en"},
+		{text: "かわいい", want: "ja"},
+		{text: "日本語です", want: "ja"},
+		{text: "예뻐요", want: "ko"},
+		{text: "123!!", want: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectLanguage(tt.text))
+		})
+	}
+}
+
+func TestExtractKeywordsSkipsStopWords(t *testing.T) {
+	words := extractKeywords("这个 The Cat")
+	assert.False(t, words["这个"])
+	assert.False(t, words["the"])
+	assert.True(t, words["cat"])
+}
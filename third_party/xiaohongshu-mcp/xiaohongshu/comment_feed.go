@@ -20,8 +20,15 @@ func NewCommentFeedAction(page *rod.Page) *CommentFeedAction {
 	return &CommentFeedAction{page: page}
 }
 
-// PostComment 发表评论到 Feed
-func (f *CommentFeedAction) PostComment(ctx context.Context, feedID, xsecToken, content string) error {
+// CommentContent 评论或回复的内容
+type CommentContent struct {
+	Text      string
+	Mentions  []string // 要 @ 的用户 ID 或昵称，通过 @ 选择弹窗插入到内容末尾
+	ImagePath string   // 附带图片的本地路径，为空表示纯文本评论
+}
+
+// PostComment 发表评论到 Feed，确认评论出现在评论区后返回新评论
+func (f *CommentFeedAction) PostComment(ctx context.Context, feedID, xsecToken string, content CommentContent) (*PostedComment, error) {
 	// 不使用 Context(ctx)，避免继承外部 context 的超时
 	page := f.page.Timeout(60 * time.Second)
 
@@ -35,52 +42,68 @@ func (f *CommentFeedAction) PostComment(ctx context.Context, feedID, xsecToken,
 
 	// 检测页面是否可访问
 	if err := checkPageAccessible(page); err != nil {
-		return err
+		return nil, err
 	}
 
 	elem, err := page.Element("div.input-box div.content-edit span")
 	if err != nil {
 		logrus.Warnf("Failed to find comment input box: %v", err)
-		return fmt.Errorf("未找到评论输入框，该帖子可能不支持评论或网页端不可访问: %w", err)
+		return nil, fmt.Errorf("未找到评论输入框，该帖子可能不支持评论或网页端不可访问: %w", err)
 	}
 
 	if err := elem.Click(proto.InputMouseButtonLeft, 1); err != nil {
 		logrus.Warnf("Failed to click comment input box: %v", err)
-		return fmt.Errorf("无法点击评论输入框: %w", err)
+		return nil, fmt.Errorf("无法点击评论输入框: %w", err)
 	}
 
 	elem2, err := page.Element("div.input-box div.content-edit p.content-input")
 	if err != nil {
 		logrus.Warnf("Failed to find comment input field: %v", err)
-		return fmt.Errorf("未找到评论输入区域: %w", err)
+		return nil, fmt.Errorf("未找到评论输入区域: %w", err)
 	}
 
-	if err := elem2.Input(content); err != nil {
+	if err := inputCommentContent(elem2, content.Text); err != nil {
 		logrus.Warnf("Failed to input comment content: %v", err)
-		return fmt.Errorf("无法输入评论内容: %w", err)
+		return nil, fmt.Errorf("无法输入评论内容: %w", err)
+	}
+
+	mentioned, err := insertMentions(elem2, commentMentionPicker, content.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachCommentImage(page, content.ImagePath); err != nil {
+		return nil, err
 	}
 
 	time.Sleep(1 * time.Second)
 
+	before := snapshotCommentIDs(page, feedID)
+
 	submitButton, err := page.Element("div.bottom button.submit")
 	if err != nil {
 		logrus.Warnf("Failed to find submit button: %v", err)
-		return fmt.Errorf("未找到提交按钮: %w", err)
+		return nil, fmt.Errorf("未找到提交按钮: %w", err)
 	}
 
 	if err := submitButton.Click(proto.InputMouseButtonLeft, 1); err != nil {
 		logrus.Warnf("Failed to click submit button: %v", err)
-		return fmt.Errorf("无法点击提交按钮: %w", err)
+		return nil, fmt.Errorf("无法点击提交按钮: %w", err)
 	}
 
-	time.Sleep(1 * time.Second)
+	posted, err := waitPostedComment(page, feedID, content.Text, before)
+	if err != nil {
+		logrus.Warnf("Comment not found after submit, feed: %s", feedID)
+		return nil, err
+	}
 
-	logrus.Infof("Comment posted successfully to feed: %s", feedID)
-	return nil
+	posted.Mentions = mentioned
+	logrus.Infof("Comment posted successfully to feed: %s, comment: %s", feedID, posted.ID)
+	return posted, nil
 }
 
-// ReplyToComment 回复指定评论
-func (f *CommentFeedAction) ReplyToComment(ctx context.Context, feedID, xsecToken, commentID, userID, content string) error {
+// ReplyToComment 回复指定评论，确认回复出现在评论区后返回新回复
+func (f *CommentFeedAction) ReplyToComment(ctx context.Context, feedID, xsecToken, commentID, userID string, content CommentContent) (*PostedComment, error) {
 	// 增加超时时间，因为需要滚动查找评论
 	// 注意：不使用 Context(ctx)，避免继承外部 context 的超时
 	page := f.page.Timeout(5 * time.Minute)
@@ -94,7 +117,7 @@ func (f *CommentFeedAction) ReplyToComment(ctx context.Context, feedID, xsecToke
 
 	// 检测页面是否可访问
 	if err := checkPageAccessible(page); err != nil {
-		return err
+		return nil, err
 	}
 
 	// 等待评论容器加载
@@ -103,7 +126,7 @@ func (f *CommentFeedAction) ReplyToComment(ctx context.Context, feedID, xsecToke
 	// 使用 Go 实现的查找逻辑
 	commentEl, err := findCommentElement(page, commentID, userID)
 	if err != nil {
-		return fmt.Errorf("无法找到评论: %w", err)
+		return nil, fmt.Errorf("无法找到评论: %w", err)
 	}
 
 	// 滚动到评论位置
@@ -116,11 +139,11 @@ func (f *CommentFeedAction) ReplyToComment(ctx context.Context, feedID, xsecToke
 	// 查找并点击回复按钮
 	replyBtn, err := commentEl.Element(".right .interactions .reply")
 	if err != nil {
-		return fmt.Errorf("无法找到回复按钮: %w", err)
+		return nil, fmt.Errorf("无法找到回复按钮: %w", err)
 	}
 
 	if err := replyBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
-		return fmt.Errorf("点击回复按钮失败: %w", err)
+		return nil, fmt.Errorf("点击回复按钮失败: %w", err)
 	}
 
 	time.Sleep(1 * time.Second)
@@ -128,29 +151,46 @@ func (f *CommentFeedAction) ReplyToComment(ctx context.Context, feedID, xsecToke
 	// 查找回复输入框
 	inputEl, err := page.Element("div.input-box div.content-edit p.content-input")
 	if err != nil {
-		return fmt.Errorf("无法找到回复输入框: %w", err)
+		return nil, fmt.Errorf("无法找到回复输入框: %w", err)
 	}
 
 	// 输入内容
-	if err := inputEl.Input(content); err != nil {
-		return fmt.Errorf("输入回复内容失败: %w", err)
+	if err := inputCommentContent(inputEl, content.Text); err != nil {
+		return nil, fmt.Errorf("输入回复内容失败: %w", err)
+	}
+
+	mentioned, err := insertMentions(inputEl, commentMentionPicker, content.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachCommentImage(page, content.ImagePath); err != nil {
+		return nil, err
 	}
 
 	time.Sleep(500 * time.Millisecond)
 
+	before := snapshotCommentIDs(page, feedID)
+
 	// 查找并点击提交按钮
 	submitBtn, err := page.Element("div.bottom button.submit")
 	if err != nil {
-		return fmt.Errorf("无法找到提交按钮: %w", err)
+		return nil, fmt.Errorf("无法找到提交按钮: %w", err)
 	}
 
 	if err := submitBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
-		return fmt.Errorf("点击提交按钮失败: %w", err)
+		return nil, fmt.Errorf("点击提交按钮失败: %w", err)
 	}
 
-	time.Sleep(2 * time.Second)
-	logrus.Infof("回复评论成功")
-	return nil
+	posted, err := waitPostedComment(page, feedID, content.Text, before)
+	if err != nil {
+		logrus.Warnf("提交回复后未在评论区找到新回复")
+		return nil, err
+	}
+
+	posted.Mentions = mentioned
+	logrus.Infof("回复评论成功: %s", posted.ID)
+	return posted, nil
 }
 
 // findCommentElement 查找指定评论元素（参考 feed_detail.go 的滚动逻辑）
@@ -0,0 +1,94 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SelectorCommentImageButton 评论框的图片按钮，部分页面点击后才渲染文件输入框
+	SelectorCommentImageButton = "div.input-box .image-btn, div.input-box .pic-btn, div.bottom .image-btn, div.bottom .pic-btn"
+	// SelectorCommentImageInput 评论框的图片文件输入框
+	SelectorCommentImageInput = `div.input-box input[type="file"], div.bottom input[type="file"], .engage-bar input[type="file"]`
+	// SelectorCommentImagePreview 上传完成后评论框中的图片预览
+	SelectorCommentImagePreview = "div.input-box .image-preview img, div.input-box .comment-image img, div.input-box .upload-image img, div.bottom .image-preview img"
+
+	// commentImageUploadTimeout 等待评论图片上传完成的最长时间
+	commentImageUploadTimeout = 30 * time.Second
+)
+
+// attachCommentImage 通过评论框的图片按钮上传一张图片，path 为空时不做任何操作
+func attachCommentImage(page *rod.Page, path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := checkCommentImage(path); err != nil {
+		return err
+	}
+
+	input, err := findCommentImageInput(page)
+	if err != nil {
+		return err
+	}
+	if err := input.SetFiles([]string{path}); err != nil {
+		return fmt.Errorf("上传评论图片失败: %w", err)
+	}
+	logrus.Infof("评论图片已提交上传: %s", path)
+
+	if _, err := page.Timeout(commentImageUploadTimeout).Element(SelectorCommentImagePreview); err != nil {
+		return fmt.Errorf("评论图片上传超时，未出现图片预览: %w", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+func findCommentImageInput(page *rod.Page) (*rod.Element, error) {
+	if has, input, err := page.Has(SelectorCommentImageInput); err == nil && has {
+		return input, nil
+	}
+
+	btn, err := page.Timeout(3 * time.Second).Element(SelectorCommentImageButton)
+	if err != nil {
+		return nil, fmt.Errorf("未找到评论图片按钮，该笔记可能不支持图片评论: %w", err)
+	}
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, fmt.Errorf("点击评论图片按钮失败: %w", err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	input, err := page.Timeout(3 * time.Second).Element(SelectorCommentImageInput)
+	if err != nil {
+		return nil, fmt.Errorf("未找到评论图片输入框: %w", err)
+	}
+	return input, nil
+}
+
+// checkCommentImage 检查图片文件存在且是评论框支持的格式
+func checkCommentImage(path string) error {
+	if !isCommentImageExt(path) {
+		return fmt.Errorf("评论图片只支持 jpg、png、webp、gif 格式: %s", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("评论图片不可用: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("评论图片路径是目录: %s", path)
+	}
+	return nil
+}
+
+func isCommentImageExt(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg", ".png", ".webp", ".gif":
+		return true
+	}
+	return false
+}
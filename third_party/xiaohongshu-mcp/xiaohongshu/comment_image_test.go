@@ -0,0 +1,41 @@
+package xiaohongshu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCommentImageExt(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "/tmp/a.jpg", want: true},
+		{path: "/tmp/a.JPEG", want: true},
+		{path: "b.png", want: true},
+		{path: "c.webp", want: true},
+		{path: "d.gif", want: true},
+		{path: "e.mp4", want: false},
+		{path: "noext", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, isCommentImageExt(tt.path))
+		})
+	}
+}
+
+func TestCheckCommentImage(t *testing.T) {
+	dir := t.TempDir()
+	img := filepath.Join(dir, "cat.png")
+	require.NoError(t, os.WriteFile(img, []byte("png"), 0o644))
+
+	assert.NoError(t, checkCommentImage(img))
+	assert.Error(t, checkCommentImage(filepath.Join(dir, "missing.png")))
+	assert.Error(t, checkCommentImage(filepath.Join(dir, "cat.txt")))
+}
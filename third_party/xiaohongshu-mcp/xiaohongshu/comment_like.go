@@ -0,0 +1,124 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// SelectorCommentLikeButton 评论内的点赞按钮（相对评论元素）
+const SelectorCommentLikeButton = ".right .interactions .like .like-wrapper"
+
+// CommentLikeAction 负责评论的点赞/取消点赞
+type CommentLikeAction struct {
+	page *rod.Page
+}
+
+func NewCommentLikeAction(page *rod.Page) *CommentLikeAction {
+	return &CommentLikeAction{page: page}
+}
+
+// LikeComment 点赞指定评论，如果已点赞则直接返回
+func (a *CommentLikeAction) LikeComment(ctx context.Context, feedID, xsecToken, commentID string) error {
+	return a.perform(ctx, feedID, xsecToken, commentID, true)
+}
+
+// UnlikeComment 取消点赞指定评论，如果未点赞则直接返回
+func (a *CommentLikeAction) UnlikeComment(ctx context.Context, feedID, xsecToken, commentID string) error {
+	return a.perform(ctx, feedID, xsecToken, commentID, false)
+}
+
+func (a *CommentLikeAction) perform(ctx context.Context, feedID, xsecToken, commentID string, targetLiked bool) error {
+	actionType := actionLike
+	if !targetLiked {
+		actionType = actionUnlike
+	}
+
+	// 不使用 Context(ctx)，避免继承外部 context 的超时
+	page := a.page.Timeout(5 * time.Minute)
+	url := makeFeedDetailURL(feedID, xsecToken)
+	logrus.Infof("Opening feed detail page for comment %s: %s, comment=%s", actionType, url, commentID)
+
+	if err := openFeedDetailPage(page, url); err != nil {
+		return err
+	}
+
+	commentEl, err := findCommentElement(page, commentID, "")
+	if err != nil {
+		return fmt.Errorf("无法找到评论: %w", err)
+	}
+
+	liked, err := commentLikedState(page, feedID, commentID)
+	if err != nil {
+		logrus.Warnf("failed to read comment like state: %v (continue to try clicking)", err)
+	} else if liked == targetLiked {
+		logrus.Infof("comment %s already in target state (liked=%v), skip clicking", commentID, liked)
+		return nil
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if err := clickCommentLike(commentEl); err != nil {
+			return err
+		}
+		time.Sleep(2 * time.Second)
+
+		liked, err := commentLikedState(page, feedID, commentID)
+		if err != nil {
+			logrus.Warnf("验证评论%s状态失败: %v", actionType, err)
+			return nil
+		}
+		if liked == targetLiked {
+			logrus.Infof("comment %s %s成功", commentID, actionType)
+			return nil
+		}
+		logrus.Warnf("comment %s %s可能未成功，状态未变化 (第 %d 次)", commentID, actionType, attempt)
+	}
+
+	return fmt.Errorf("评论%s失败，点击后状态未变化", actionType)
+}
+
+func clickCommentLike(commentEl *rod.Element) error {
+	commentEl.MustScrollIntoView()
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	btn, err := commentEl.Element(SelectorCommentLikeButton)
+	if err != nil {
+		return fmt.Errorf("未找到评论点赞按钮: %w", err)
+	}
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击评论点赞按钮失败: %w", err)
+	}
+	return nil
+}
+
+// commentLikedState 从 __INITIAL_STATE__ 读取评论的点赞状态
+func commentLikedState(page *rod.Page, feedID, commentID string) (bool, error) {
+	comments, err := extractCommentList(page, feedID)
+	if err != nil {
+		return false, err
+	}
+	liked, ok := findCommentLiked(comments.List, commentID)
+	if !ok {
+		return false, fmt.Errorf("comment %s not in comment list", commentID)
+	}
+	return liked, nil
+}
+
+// findCommentLiked 在一级评论及其回复中查找评论的点赞状态
+func findCommentLiked(list []Comment, commentID string) (liked bool, ok bool) {
+	for _, c := range list {
+		if c.ID == commentID {
+			return c.Liked, true
+		}
+		for _, sub := range c.SubComments {
+			if sub.ID == commentID {
+				return sub.Liked, true
+			}
+		}
+	}
+	return false, false
+}
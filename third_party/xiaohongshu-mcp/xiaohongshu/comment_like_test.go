@@ -0,0 +1,33 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCommentLiked(t *testing.T) {
+	list := []Comment{
+		{ID: "c1", Liked: true, SubComments: []Comment{{ID: "r1", Liked: false}}},
+		{ID: "c2", SubComments: []Comment{{ID: "r2", Liked: true}}},
+	}
+
+	tests := []struct {
+		id        string
+		wantLiked bool
+		wantOK    bool
+	}{
+		{id: "c1", wantLiked: true, wantOK: true},
+		{id: "r1", wantLiked: false, wantOK: true},
+		{id: "r2", wantLiked: true, wantOK: true},
+		{id: "x", wantLiked: false, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			liked, ok := findCommentLiked(list, tt.id)
+			assert.Equal(t, tt.wantLiked, liked)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+const (
+	defaultCommentPageSize = 10
+	maxCommentPageSize     = 50
+	// commentPageMaxScrolls 单次分页最多滚动次数，每次滚动通常加载 10 条一级评论；
+	// 在新页面上游标在约 300 条之后时返回 ErrCursorTooDeep
+	commentPageMaxScrolls = 30
+	// commentPageStagnantLimit 评论数连续多少次未增加即认为已无法继续加载
+	commentPageStagnantLimit = 5
+)
+
+// CommentPage 一页一级评论
+type CommentPage struct {
+	FeedID   string    `json:"feedId"`
+	Comments []Comment `json:"comments"`
+	Cursor   string    `json:"cursor"`  // 下一页游标，传给下次请求的 cursor 参数
+	HasMore  bool      `json:"hasMore"` // 是否还有更多评论
+}
+
+// ListComments 返回 cursor 之后的一页一级评论
+// cursor 为上一页返回的游标（即上一页最后一条评论的 ID），为空表示从第一条开始；
+// 只滚动加载到足够返回本页的位置，不会加载全部评论。
+// 同一个 action 上次读取的是同一篇笔记时，不重新打开页面，已加载的评论和滚动位置都还在，
+// 翻页只需继续向下加载本页的评论
+func (f *FeedDetailAction) ListComments(ctx context.Context, feedID, xsecToken, cursor string, limit int) (*CommentPage, error) {
+	if limit <= 0 {
+		limit = defaultCommentPageSize
+	}
+	if limit > maxCommentPageSize {
+		limit = maxCommentPageSize
+	}
+
+	page := f.page.Context(ctx).Timeout(3 * time.Minute)
+	url := makeFeedDetailURL(feedID, xsecToken)
+
+	resume := cursor != "" && f.commentsFeed == feedID
+	logrus.Infof("分页读取评论: %s, cursor=%q, limit=%d, resume=%v", url, cursor, limit, resume)
+
+	resp := &CommentPage{FeedID: feedID, Comments: []Comment{}}
+	if !resume {
+		f.commentsFeed = ""
+		if err := openFeedDetailPage(page, url); err != nil {
+			return nil, err
+		}
+		if checkNoCommentsArea(page) {
+			logrus.Infof("✓ 检测到无评论区域（这是一片荒地）")
+			return resp, nil
+		}
+		f.commentsFeed = feedID
+	}
+
+	comments, err := extractCommentList(page, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resume && !commentPageReady(comments, cursor, limit) {
+		scrollToCommentsArea(page)
+		sleepRandom(humanDelayRange.min, humanDelayRange.max)
+	}
+
+	stagnant := 0
+	for i := 0; i < commentPageMaxScrolls && !commentPageReady(comments, cursor, limit); i++ {
+		lastCount := len(comments.List)
+
+		scrollToLastComment(page)
+		sleepRandom(postScrollRange.min, postScrollRange.max)
+		humanScroll(page, "normal", stagnant > 0, 1)
+		sleepRandom(readTimeRange.min, readTimeRange.max)
+
+		if comments, err = extractCommentList(page, feedID); err != nil {
+			return nil, err
+		}
+
+		if len(comments.List) > lastCount {
+			stagnant = 0
+			continue
+		}
+		stagnant++
+		if stagnant >= commentPageStagnantLimit || checkEndContainer(page) {
+			logrus.Infof("评论无法继续加载，当前 %d 条", len(comments.List))
+			break
+		}
+	}
+
+	list, next, hasMore, found := sliceCommentPage(comments.List, cursor, limit)
+	if !found {
+		// 平台还有未加载的评论，说明是滚动次数用完了，而不是游标对应的评论不存在
+		if comments.HasMore && !checkEndContainer(page) {
+			return nil, fmt.Errorf("%w: 已加载 %d 条评论仍未到达游标 %s", errors.ErrCursorTooDeep, len(comments.List), cursor)
+		}
+		return nil, fmt.Errorf("未找到游标 %s 对应的评论，评论可能已被删除，请从头读取", cursor)
+	}
+
+	resp.Comments = list
+	resp.Cursor = next
+	resp.HasMore = hasMore || comments.HasMore
+	return resp, nil
+}
+
+// extractCommentList 从 __INITIAL_STATE__ 中读取当前已加载的评论
+func extractCommentList(page *rod.Page, feedID string) (*CommentList, error) {
+	result := page.MustEval(`(feedID) => {
+		const state = window.__INITIAL_STATE__;
+		if (!state || !state.note || !state.note.noteDetailMap) {
+			return "";
+		}
+		const detail = state.note.noteDetailMap[feedID];
+		if (!detail || !detail.comments) {
+			return "";
+		}
+		return JSON.stringify(detail.comments);
+	}`, feedID).String()
+
+	if result == "" {
+		return nil, fmt.Errorf("无法获取评论数据")
+	}
+
+	var comments CommentList
+	if err := json.Unmarshal([]byte(result), &comments); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal comments: %w", err)
+	}
+	return &comments, nil
+}
+
+// commentPageReady 判断已加载的评论是否足够返回本页
+func commentPageReady(comments *CommentList, cursor string, limit int) bool {
+	if !comments.HasMore {
+		return true
+	}
+	_, _, hasMore, found := sliceCommentPage(comments.List, cursor, limit)
+	return found && hasMore
+}
+
+// sliceCommentPage 取出 cursor 之后的 limit 条评论
+// 返回本页评论、下一页游标、已加载的评论中是否还有剩余，以及 cursor 是否找到
+func sliceCommentPage(list []Comment, cursor string, limit int) ([]Comment, string, bool, bool) {
+	start := 0
+	if cursor != "" {
+		start = -1
+		for i, c := range list {
+			if c.ID == cursor {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, "", false, false
+		}
+	}
+
+	end := min(start+limit, len(list))
+	page := append([]Comment{}, list[start:end]...)
+
+	next := cursor
+	if len(page) > 0 {
+		next = page[len(page)-1].ID
+	}
+	return page, next, end < len(list), true
+}
@@ -0,0 +1,54 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceCommentPage(t *testing.T) {
+	list := []Comment{{ID: "c1"}, {ID: "c2"}, {ID: "c3"}, {ID: "c4"}, {ID: "c5"}}
+
+	ids := func(comments []Comment) []string {
+		var out []string
+		for _, c := range comments {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name        string
+		cursor      string
+		limit       int
+		wantIDs     []string
+		wantNext    string
+		wantHasMore bool
+		wantFound   bool
+	}{
+		{name: "第一页", cursor: "", limit: 2, wantIDs: []string{"c1", "c2"}, wantNext: "c2", wantHasMore: true, wantFound: true},
+		{name: "中间页", cursor: "c2", limit: 2, wantIDs: []string{"c3", "c4"}, wantNext: "c4", wantHasMore: true, wantFound: true},
+		{name: "最后一页", cursor: "c4", limit: 2, wantIDs: []string{"c5"}, wantNext: "c5", wantHasMore: false, wantFound: true},
+		{name: "已读完", cursor: "c5", limit: 2, wantIDs: nil, wantNext: "c5", wantHasMore: false, wantFound: true},
+		{name: "游标不存在", cursor: "x", limit: 2, wantIDs: nil, wantNext: "", wantHasMore: false, wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, next, hasMore, found := sliceCommentPage(list, tt.cursor, tt.limit)
+			assert.Equal(t, tt.wantIDs, ids(page))
+			assert.Equal(t, tt.wantNext, next)
+			assert.Equal(t, tt.wantHasMore, hasMore)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}
+
+func TestCommentPageReady(t *testing.T) {
+	list := []Comment{{ID: "c1"}, {ID: "c2"}, {ID: "c3"}}
+
+	assert.True(t, commentPageReady(&CommentList{List: list, HasMore: true}, "", 2))
+	assert.False(t, commentPageReady(&CommentList{List: list, HasMore: true}, "c1", 2))
+	assert.False(t, commentPageReady(&CommentList{List: list, HasMore: true}, "c9", 2))
+	assert.True(t, commentPageReady(&CommentList{List: list, HasMore: false}, "c1", 2))
+}
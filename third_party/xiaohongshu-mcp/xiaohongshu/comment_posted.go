@@ -0,0 +1,157 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+// postedCommentTimeout 点击发送后等待新评论出现的最长时间
+const postedCommentTimeout = 10 * time.Second
+
+// postedCommentNoisePattern 表情代码和 @ 提及：DOM 中表情渲染为图片、提及渲染为链接，
+// 与发送的文本对不上，匹配前两边都去掉
+var postedCommentNoisePattern = regexp.MustCompile(`\[[^\[\]\s]{1,10}\]|@[^\s@#\[\]]+`)
+
+// PostedComment 刚发出的评论或回复
+type PostedComment struct {
+	ID         string `json:"id"`
+	Content    string `json:"content"`
+	CreateTime int64  `json:"createTime"` // 毫秒时间戳，仅从 DOM 中找到时为 0
+	// Mentions 发送前在输入框中插入并核对过的提及用户
+	Mentions []MentionUser `json:"mentions,omitempty"`
+}
+
+// commentCandidate 页面上的一条评论（一级评论或回复）
+type commentCandidate struct {
+	ID         string `json:"id"`
+	Content    string `json:"content"`
+	CreateTime int64  `json:"createTime"`
+	AuthorID   string `json:"authorId"` // 读不到作者时为空
+}
+
+// collectComments 收集 __INITIAL_STATE__ 和 DOM 中当前可见的全部评论
+func collectComments(page *rod.Page, feedID string) []commentCandidate {
+	result, err := page.Eval(`(feedID) => {
+		const items = [];
+		const state = window.__INITIAL_STATE__;
+		const detail = state && state.note && state.note.noteDetailMap && state.note.noteDetailMap[feedID];
+		const list = detail && detail.comments && detail.comments.list;
+		if (Array.isArray(list)) {
+			for (const c of list) {
+				items.push({id: c.id || "", content: c.content || "", createTime: c.createTime || 0, authorId: (c.userInfo && c.userInfo.userId) || ""});
+				for (const sub of (c.subComments || [])) {
+					items.push({id: sub.id || "", content: sub.content || "", createTime: sub.createTime || 0, authorId: (sub.userInfo && sub.userInfo.userId) || ""});
+				}
+			}
+		}
+
+		document.querySelectorAll('[id^="comment-"]').forEach(el => {
+			const textEl = el.querySelector('.note-text, .content');
+			const authorEl = el.querySelector('.author a[href*="/user/profile/"], a.name[href*="/user/profile/"]');
+			const match = authorEl && (authorEl.getAttribute('href') || "").match(/\/user\/profile\/([0-9a-zA-Z]+)/);
+			items.push({
+				id: el.id.slice("comment-".length),
+				content: textEl ? textEl.innerText : "",
+				createTime: 0,
+				authorId: match ? match[1] : "",
+			});
+		});
+		return JSON.stringify(items);
+	}`, feedID)
+	if err != nil {
+		logrus.Debugf("收集评论失败: %v", err)
+		return nil
+	}
+
+	var items []commentCandidate
+	if err := json.Unmarshal([]byte(result.Value.String()), &items); err != nil {
+		logrus.Debugf("解析评论失败: %v", err)
+		return nil
+	}
+	return items
+}
+
+// snapshotCommentIDs 记录发送前页面上已有的评论 ID
+func snapshotCommentIDs(page *rod.Page, feedID string) map[string]bool {
+	ids := make(map[string]bool)
+	for _, c := range collectComments(page, feedID) {
+		ids[c.ID] = true
+	}
+	return ids
+}
+
+// waitPostedComment 等待发送前不存在、内容匹配且作者是当前账号的新评论出现
+func waitPostedComment(page *rod.Page, feedID, content string, before map[string]bool) (*PostedComment, error) {
+	me, err := currentUserID(page)
+	if err != nil {
+		logrus.Warnf("读取当前账号失败，只按内容匹配新评论: %v", err)
+	}
+
+	deadline := time.Now().Add(postedCommentTimeout)
+	for {
+		time.Sleep(1 * time.Second)
+
+		if posted, ok := matchPostedComment(collectComments(page, feedID), before, content, me); ok {
+			return posted, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.ErrCommentNotPosted
+		}
+	}
+}
+
+// matchPostedComment 在新出现的评论中查找自己发的一条
+// 去掉表情和提及后，新评论的文本必须包含发送的全部文本；能读到作者时作者必须是 me。
+// 只有表情或提及的评论没有可比对的文本，必须读到作者才算匹配。
+// 同一条评论可能同时出现在 state 和 DOM 中，优先使用带创建时间的 state 数据
+func matchPostedComment(items []commentCandidate, before map[string]bool, content, me string) (*PostedComment, bool) {
+	want := normalizeCommentText(postedCommentNoisePattern.ReplaceAllString(content, ""))
+
+	// DOM 中可能读不到作者，同一条评论在 state 中的作者也算数
+	authors := make(map[string]string)
+	for _, item := range items {
+		if item.AuthorID != "" {
+			authors[item.ID] = item.AuthorID
+		}
+	}
+
+	var found *PostedComment
+	for _, item := range items {
+		if item.ID == "" || before[item.ID] {
+			continue
+		}
+		author := authors[item.ID]
+		if me != "" && author != "" && author != me {
+			continue
+		}
+		if want == "" && (me == "" || author != me) {
+			continue
+		}
+		got := normalizeCommentText(postedCommentNoisePattern.ReplaceAllString(item.Content, ""))
+		if !strings.Contains(got, want) {
+			continue
+		}
+
+		if found == nil || (found.CreateTime == 0 && item.CreateTime > 0 && found.ID == item.ID) {
+			found = &PostedComment{ID: item.ID, Content: item.Content, CreateTime: item.CreateTime}
+		}
+	}
+	return found, found != nil
+}
+
+// normalizeCommentText 去除空白，避免换行、缩进等渲染差异影响匹配
+func normalizeCommentText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,76 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPostedComment(t *testing.T) {
+	before := map[string]bool{"old": true}
+
+	t.Run("优先使用state中的创建时间", func(t *testing.T) {
+		items := []commentCandidate{
+			{ID: "old", Content: "好可爱"},
+			{ID: "new", Content: "好可爱 的猫"},
+			{ID: "new", Content: "好可爱的猫", CreateTime: 1700000000000},
+		}
+		posted, ok := matchPostedComment(items, before, "好可爱的猫", "me")
+		require.True(t, ok)
+		assert.Equal(t, "new", posted.ID)
+		assert.Equal(t, int64(1700000000000), posted.CreateTime)
+	})
+
+	t.Run("DOM中表情被渲染为图片", func(t *testing.T) {
+		items := []commentCandidate{{ID: "new", Content: "笑死"}}
+		posted, ok := matchPostedComment(items, before, "笑死[笑哭R]", "me")
+		require.True(t, ok)
+		assert.Equal(t, "new", posted.ID)
+	})
+
+	t.Run("内容不匹配", func(t *testing.T) {
+		items := []commentCandidate{{ID: "other", Content: "别人的评论"}}
+		_, ok := matchPostedComment(items, before, "好可爱的猫", "me")
+		assert.False(t, ok)
+	})
+
+	t.Run("只有发送前已存在的评论", func(t *testing.T) {
+		items := []commentCandidate{{ID: "old", Content: "好可爱的猫"}}
+		_, ok := matchPostedComment(items, before, "好可爱的猫", "me")
+		assert.False(t, ok)
+	})
+
+	t.Run("别人同时发的短评论不算", func(t *testing.T) {
+		items := []commentCandidate{
+			{ID: "stranger", Content: "好可爱", AuthorID: "other"},
+			{ID: "stranger2", Content: "哈哈"},
+		}
+		_, ok := matchPostedComment(items, before, "好可爱的猫[哈哈R]", "me")
+		assert.False(t, ok)
+	})
+
+	t.Run("内容包含我们的文本但作者是别人", func(t *testing.T) {
+		items := []commentCandidate{
+			{ID: "stranger", Content: "好可爱的猫猫"},
+			{ID: "stranger", Content: "好可爱的猫猫", AuthorID: "other", CreateTime: 1700000000000},
+			{ID: "mine", Content: "好可爱的猫 @小明", AuthorID: "me", CreateTime: 1700000001000},
+		}
+		posted, ok := matchPostedComment(items, before, "好可爱的猫", "me")
+		require.True(t, ok)
+		assert.Equal(t, "mine", posted.ID)
+	})
+
+	t.Run("只有表情时必须是自己发的", func(t *testing.T) {
+		items := []commentCandidate{
+			{ID: "stranger", Content: "", AuthorID: "other"},
+			{ID: "mine", Content: "[赞R]", AuthorID: "me"},
+		}
+		posted, ok := matchPostedComment(items, before, "[赞R]", "me")
+		require.True(t, ok)
+		assert.Equal(t, "mine", posted.ID)
+
+		_, ok = matchPostedComment(items, before, "[赞R]", "")
+		assert.False(t, ok)
+	})
+}
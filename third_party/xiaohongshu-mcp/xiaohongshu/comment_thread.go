@@ -0,0 +1,171 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultThreadReplyLimit = 20
+	maxThreadReplyLimit     = 100
+	// threadMaxExpandClicks 单个评论最多点击"展开更多回复"的次数
+	threadMaxExpandClicks = 20
+)
+
+// CommentThread 一条一级评论及其回复树
+type CommentThread struct {
+	FeedID  string  `json:"feedId"`
+	Comment Comment `json:"comment"`
+	// ReplyCount 本次返回的回复数量（含嵌套）
+	ReplyCount int `json:"replyCount"`
+	// HasMore 是否还有未返回的回复
+	HasMore bool `json:"hasMore"`
+}
+
+// GetCommentThread 只展开指定评论的回复，返回以一级评论为根的回复树
+// commentID 可以是一级评论，也可以是其下的某条回复；limit 为最多返回的回复数
+func (f *FeedDetailAction) GetCommentThread(ctx context.Context, feedID, xsecToken, commentID string, limit int) (*CommentThread, error) {
+	if limit <= 0 {
+		limit = defaultThreadReplyLimit
+	}
+	if limit > maxThreadReplyLimit {
+		limit = maxThreadReplyLimit
+	}
+
+	page := f.page.Context(ctx).Timeout(5 * time.Minute)
+	url := makeFeedDetailURL(feedID, xsecToken)
+
+	logrus.Infof("读取评论回复: %s, commentID=%s, limit=%d", url, commentID, limit)
+
+	if err := openFeedDetailPage(page, url); err != nil {
+		return nil, err
+	}
+
+	el, err := findCommentElement(page, commentID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// 只在该评论所在的一级评论容器内展开回复
+	container := el
+	if parents, err := el.Parents(".parent-comment"); err == nil && len(parents) > 0 {
+		container = parents[0]
+	}
+
+	comments, err := extractCommentList(page, feedID)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := findThreadRoot(comments.List, commentID)
+	if !ok {
+		return nil, fmt.Errorf("评论 %s 未出现在页面数据中", commentID)
+	}
+
+	for clicks := 0; clicks < threadMaxExpandClicks && len(root.SubComments) < limit; clicks++ {
+		buttons, err := container.Elements(".show-more")
+		if err != nil || len(buttons) == 0 {
+			break
+		}
+
+		button := buttons[len(buttons)-1]
+		if !isElementClickable(button) {
+			break
+		}
+		text, _ := button.Text()
+		if !clickElementWithHumanBehavior(page, button, text) {
+			break
+		}
+
+		if comments, err = extractCommentList(page, feedID); err != nil {
+			return nil, err
+		}
+		next, ok := findThreadRoot(comments.List, commentID)
+		if !ok {
+			return nil, fmt.Errorf("评论 %s 在展开回复后消失", commentID)
+		}
+		if len(next.SubComments) <= len(root.SubComments) {
+			logrus.Infof("展开回复后数量未增加，停止展开")
+			root = next
+			break
+		}
+		root = next
+	}
+
+	replies := root.SubComments
+	hasMore := root.SubCommentHasMore
+	if len(replies) > limit {
+		replies = replies[:limit]
+		hasMore = true
+	}
+
+	thread := &CommentThread{
+		FeedID:     feedID,
+		Comment:    buildReplyTree(root, replies),
+		ReplyCount: len(replies),
+		HasMore:    hasMore,
+	}
+	logrus.Infof("✓ 读取评论 %s 的回复 %d 条, hasMore=%v", root.ID, thread.ReplyCount, thread.HasMore)
+	return thread, nil
+}
+
+// findThreadRoot 查找 commentID 所在的一级评论，commentID 可以是一级评论或其回复
+func findThreadRoot(list []Comment, commentID string) (Comment, bool) {
+	for _, c := range list {
+		if c.ID == commentID {
+			return c, true
+		}
+		for _, sub := range c.SubComments {
+			if sub.ID == commentID {
+				return c, true
+			}
+		}
+	}
+	return Comment{}, false
+}
+
+// buildReplyTree 将平铺的回复按 targetComment 挂到被回复的评论下
+// 回复的目标不在本次返回的回复中时，挂在一级评论下
+func buildReplyTree(root Comment, replies []Comment) Comment {
+	nodes := make(map[string]*Comment, len(replies))
+	for i := range replies {
+		reply := replies[i]
+		reply.SubComments = nil
+		nodes[reply.ID] = &reply
+	}
+
+	children := make(map[string][]string)
+	var top []string
+	for _, reply := range replies {
+		target := ""
+		if reply.TargetComment != nil {
+			target = reply.TargetComment.ID
+		}
+		if _, ok := nodes[target]; ok && target != reply.ID {
+			children[target] = append(children[target], reply.ID)
+		} else {
+			top = append(top, reply.ID)
+		}
+	}
+
+	var build func(id string, depth int) Comment
+	build = func(id string, depth int) Comment {
+		node := *nodes[id]
+		// 回复之间不会成环，深度限制仅作防御
+		if depth < len(replies) {
+			for _, child := range children[id] {
+				node.SubComments = append(node.SubComments, build(child, depth+1))
+			}
+		}
+		return node
+	}
+
+	tree := root
+	tree.SubComments = make([]Comment, 0, len(top))
+	for _, id := range top {
+		tree.SubComments = append(tree.SubComments, build(id, 0))
+	}
+	return tree
+}
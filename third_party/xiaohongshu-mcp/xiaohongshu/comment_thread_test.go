@@ -0,0 +1,52 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindThreadRoot(t *testing.T) {
+	list := []Comment{
+		{ID: "c1", SubComments: []Comment{{ID: "r1"}}},
+		{ID: "c2", SubComments: []Comment{{ID: "r2"}, {ID: "r3"}}},
+	}
+
+	root, ok := findThreadRoot(list, "c2")
+	require.True(t, ok)
+	assert.Equal(t, "c2", root.ID)
+
+	root, ok = findThreadRoot(list, "r3")
+	require.True(t, ok)
+	assert.Equal(t, "c2", root.ID)
+
+	_, ok = findThreadRoot(list, "x")
+	assert.False(t, ok)
+}
+
+func TestBuildReplyTree(t *testing.T) {
+	root := Comment{ID: "c1", Content: "一级评论"}
+	replies := []Comment{
+		{ID: "r1", TargetComment: &TargetComment{ID: "c1"}},
+		{ID: "r2", TargetComment: &TargetComment{ID: "r1"}},
+		{ID: "r3", TargetComment: &TargetComment{ID: "r2"}},
+		{ID: "r4", TargetComment: &TargetComment{ID: "missing"}},
+		{ID: "r5"},
+	}
+
+	tree := buildReplyTree(root, replies)
+
+	assert.Equal(t, "c1", tree.ID)
+	assert.Equal(t, "一级评论", tree.Content)
+	require.Len(t, tree.SubComments, 3)
+	assert.Equal(t, "r1", tree.SubComments[0].ID)
+	assert.Equal(t, "r4", tree.SubComments[1].ID)
+	assert.Equal(t, "r5", tree.SubComments[2].ID)
+
+	require.Len(t, tree.SubComments[0].SubComments, 1)
+	r2 := tree.SubComments[0].SubComments[0]
+	assert.Equal(t, "r2", r2.ID)
+	require.Len(t, r2.SubComments, 1)
+	assert.Equal(t, "r3", r2.SubComments[0].ID)
+}
@@ -0,0 +1,56 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+)
+
+// 小红书页面状态中的计数都是展示文本（如 "1.2万"、"10+"），
+// 这里在反序列化时同步解析出整数字段，调用方无需再关心格式。
+
+// UnmarshalJSON 反序列化互动信息并解析计数
+func (i *InteractInfo) UnmarshalJSON(data []byte) error {
+	type alias InteractInfo
+	var v alias
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	v.LikedCountNum = xhsutil.ParseCount(v.LikedCount)
+	v.SharedCountNum = xhsutil.ParseCount(v.SharedCount)
+	v.CommentCountNum = xhsutil.ParseCount(v.CommentCount)
+	v.CollectedCountNum = xhsutil.ParseCount(v.CollectedCount)
+
+	*i = InteractInfo(v)
+	return nil
+}
+
+// UnmarshalJSON 反序列化评论并解析点赞数和回复数
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	type alias Comment
+	var v alias
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	v.LikeCountNum = xhsutil.ParseCount(v.LikeCount)
+	v.SubCommentCountNum = xhsutil.ParseCount(v.SubCommentCount)
+
+	*c = Comment(v)
+	return nil
+}
+
+// UnmarshalJSON 反序列化用户互动数据并解析数量
+func (u *UserInteractions) UnmarshalJSON(data []byte) error {
+	type alias UserInteractions
+	var v alias
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	v.CountNum = xhsutil.ParseCount(v.Count)
+
+	*u = UserInteractions(v)
+	return nil
+}
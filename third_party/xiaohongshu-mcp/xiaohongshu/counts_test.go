@@ -0,0 +1,46 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalCounts(t *testing.T) {
+	raw := `{
+		"note": {
+			"interactInfo": {"likedCount": "1.2万", "sharedCount": "10+", "commentCount": "356", "collectedCount": "2w"}
+		},
+		"comments": {
+			"list": [{
+				"id": "c1",
+				"likeCount": "1,024",
+				"subCommentCount": "12",
+				"subComments": [{"id": "c2", "likeCount": "赞", "subCommentCount": "0"}]
+			}]
+		}
+	}`
+
+	var resp FeedDetailResponse
+	require.NoError(t, json.Unmarshal([]byte(raw), &resp))
+
+	info := resp.Note.InteractInfo
+	assert.Equal(t, "1.2万", info.LikedCount)
+	assert.Equal(t, int64(12000), info.LikedCountNum)
+	assert.Equal(t, int64(10), info.SharedCountNum)
+	assert.Equal(t, int64(356), info.CommentCountNum)
+	assert.Equal(t, int64(20000), info.CollectedCountNum)
+
+	require.Len(t, resp.Comments.List, 1)
+	c := resp.Comments.List[0]
+	assert.Equal(t, int64(1024), c.LikeCountNum)
+	assert.Equal(t, int64(12), c.SubCommentCountNum)
+	require.Len(t, c.SubComments, 1)
+	assert.Equal(t, int64(0), c.SubComments[0].LikeCountNum)
+
+	var interactions []UserInteractions
+	require.NoError(t, json.Unmarshal([]byte(`[{"type":"fans","name":"粉丝","count":"3.4万"}]`), &interactions))
+	assert.Equal(t, int64(34000), interactions[0].CountNum)
+}
@@ -0,0 +1,405 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+)
+
+const (
+	// 创作中心数据中心的三个页面：账号概览、粉丝数据、笔记数据
+	urlOfAccountStats = "https://creator.xiaohongshu.com/statistics/account"
+	urlOfFansStats    = "https://creator.xiaohongshu.com/statistics/fans-data"
+	urlOfNoteStats    = "https://creator.xiaohongshu.com/statistics/data-analysis"
+
+	// SelectorStatsItems 数据中心的数据卡片，每张卡片包含一个名称和一个数值
+	SelectorStatsItems = ".data-card, .overview-item, .statistic-item, .data-item"
+	// SelectorStatsPeriod 数据中心当前选中的统计周期
+	SelectorStatsPeriod = ".time-select .active, .date-tabs .active, .d-tabs-header .active"
+	// SelectorNoteStatsRows 笔记数据页中的笔记，表格行或卡片
+	SelectorNoteStatsRows = ".note-data-table tbody tr, .data-analysis-table tbody tr, .note-data-list .note-item"
+)
+
+// AccountStats 账号在统计周期内的数据
+type AccountStats struct {
+	Impressions     int64   `json:"impressions"`     // 曝光数
+	Views           int64   `json:"views"`           // 观看数
+	CoverClickRate  float64 `json:"coverClickRate"`  // 封面点击率，百分比
+	AvgViewSeconds  float64 `json:"avgViewSeconds"`  // 平均观看时长，秒
+	CompletionRate  float64 `json:"completionRate"`  // 视频完播率，百分比
+	Likes           int64   `json:"likes"`           // 点赞数
+	Comments        int64   `json:"comments"`        // 评论数
+	Collects        int64   `json:"collects"`        // 收藏数
+	Shares          int64   `json:"shares"`          // 分享数
+	NewFollowers    int64   `json:"newFollowers"`    // 新增关注
+	LostFollowers   int64   `json:"lostFollowers"`   // 取消关注
+	NetFollowers    int64   `json:"netFollowers"`    // 净涨粉
+	TotalFollowers  int64   `json:"totalFollowers"`  // 总粉丝数
+	ProfileVisitors int64   `json:"profileVisitors"` // 主页访客
+}
+
+// NoteStats 单篇笔记的累计数据
+type NoteStats struct {
+	NoteID         string  `json:"noteId,omitempty"` // 页面未提供笔记链接时为空，按标题区分
+	Title          string  `json:"title"`
+	PublishTime    string  `json:"publishTime,omitempty"` // 页面显示的发布时间
+	Impressions    int64   `json:"impressions"`
+	Views          int64   `json:"views"`
+	CoverClickRate float64 `json:"coverClickRate"`
+	AvgViewSeconds float64 `json:"avgViewSeconds"`
+	CompletionRate float64 `json:"completionRate"` // 视频完播率，图文笔记为 0
+	Likes          int64   `json:"likes"`
+	Comments       int64   `json:"comments"`
+	Collects       int64   `json:"collects"`
+	Shares         int64   `json:"shares"`
+	NewFollowers   int64   `json:"newFollowers"` // 笔记带来的涨粉
+}
+
+// CreatorStats 一次采集得到的创作中心数据快照
+type CreatorStats struct {
+	CollectedAt time.Time    `json:"collectedAt"`
+	Period      string       `json:"period,omitempty"` // 账号数据的统计周期，如 "近7日"
+	Account     AccountStats `json:"account"`
+	Notes       []NoteStats  `json:"notes"`
+}
+
+// Metrics 以指标名返回账号数据，用于计算趋势
+func (a AccountStats) Metrics() map[string]float64 {
+	return map[string]float64{
+		"impressions":     float64(a.Impressions),
+		"views":           float64(a.Views),
+		"coverClickRate":  a.CoverClickRate,
+		"avgViewSeconds":  a.AvgViewSeconds,
+		"completionRate":  a.CompletionRate,
+		"likes":           float64(a.Likes),
+		"comments":        float64(a.Comments),
+		"collects":        float64(a.Collects),
+		"shares":          float64(a.Shares),
+		"newFollowers":    float64(a.NewFollowers),
+		"lostFollowers":   float64(a.LostFollowers),
+		"netFollowers":    float64(a.NetFollowers),
+		"totalFollowers":  float64(a.TotalFollowers),
+		"profileVisitors": float64(a.ProfileVisitors),
+	}
+}
+
+// Metrics 以指标名返回笔记数据，用于计算趋势
+func (n NoteStats) Metrics() map[string]float64 {
+	return map[string]float64{
+		"impressions":    float64(n.Impressions),
+		"views":          float64(n.Views),
+		"coverClickRate": n.CoverClickRate,
+		"avgViewSeconds": n.AvgViewSeconds,
+		"completionRate": n.CompletionRate,
+		"likes":          float64(n.Likes),
+		"comments":       float64(n.Comments),
+		"collects":       float64(n.Collects),
+		"shares":         float64(n.Shares),
+		"newFollowers":   float64(n.NewFollowers),
+	}
+}
+
+// CreatorStatsAction 从创作中心数据中心读取账号和笔记数据
+type CreatorStatsAction struct {
+	page *rod.Page
+}
+
+func NewCreatorStatsAction(page *rod.Page) *CreatorStatsAction {
+	return &CreatorStatsAction{page: page}
+}
+
+// Collect 依次打开账号概览、粉丝数据和笔记数据页，返回一份数据快照
+// 笔记数据只读取第一页，即最近发布的笔记
+func (a *CreatorStatsAction) Collect(ctx context.Context) (*CreatorStats, error) {
+	page := a.page.Context(ctx).Timeout(3 * time.Minute)
+	stats := &CreatorStats{CollectedAt: time.Now()}
+
+	if err := openStatsPage(page, urlOfAccountStats, SelectorStatsItems); err != nil {
+		return nil, fmt.Errorf("读取账号概览失败: %w", err)
+	}
+	stats.Period = readStatsPeriod(page)
+	for _, item := range readStatsItems(page) {
+		applyAccountStat(&stats.Account, item.Label, item.Value)
+	}
+
+	// 粉丝数据页缺失不影响其他数据
+	if err := openStatsPage(page, urlOfFansStats, SelectorStatsItems); err != nil {
+		logrus.Warnf("读取粉丝数据失败: %v", err)
+	} else {
+		for _, item := range readStatsItems(page) {
+			applyAccountStat(&stats.Account, item.Label, item.Value)
+		}
+	}
+
+	if err := openStatsPage(page, urlOfNoteStats, SelectorNoteStatsRows); err != nil {
+		// 还没有发布过笔记时没有笔记数据
+		logrus.Warnf("读取笔记数据失败: %v", err)
+		stats.Notes = []NoteStats{}
+	} else {
+		stats.Notes = readNoteStatsRows(page)
+	}
+
+	logrus.Infof("创作中心数据采集完成: 周期=%s, 笔记 %d 篇", stats.Period, len(stats.Notes))
+	return stats, nil
+}
+
+func openStatsPage(page *rod.Page, url, selector string) error {
+	logrus.Infof("打开数据中心: %s", url)
+	if err := page.Navigate(url); err != nil {
+		return fmt.Errorf("打开 %s 失败: %w", url, err)
+	}
+	if err := page.WaitLoad(); err != nil {
+		logrus.Warnf("等待数据中心加载出现问题: %v，继续尝试", err)
+	}
+	if err := page.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待 DOM 稳定出现问题: %v，继续尝试", err)
+	}
+
+	if _, err := page.Timeout(15 * time.Second).Element(selector); err != nil {
+		return fmt.Errorf("页面没有数据，或创作中心未登录: %w", err)
+	}
+	sleepRandom(readTimeRange.min, readTimeRange.max)
+	return nil
+}
+
+func readStatsPeriod(page *rod.Page) string {
+	el, err := page.Timeout(2 * time.Second).Element(SelectorStatsPeriod)
+	if err != nil {
+		return ""
+	}
+	text, err := el.Text()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(text)
+}
+
+// readStatsItems 读取数据卡片的名称和数值
+func readStatsItems(page *rod.Page) []rawNoteStat {
+	result := page.MustEval(`(selector) => {
+		const items = [];
+		document.querySelectorAll(selector).forEach((el) => {
+			const label = el.querySelector('.label, .title, .name, .data-name');
+			const value = el.querySelector('.value, .num, .count, .data-value');
+			if (!label || !value) {
+				return;
+			}
+			items.push({label: label.textContent.trim(), value: value.textContent.trim()});
+		});
+		return JSON.stringify(items);
+	}`, SelectorStatsItems).String()
+
+	var items []rawNoteStat
+	if err := json.Unmarshal([]byte(result), &items); err != nil {
+		logrus.Warnf("解析数据卡片失败: %v", err)
+		return nil
+	}
+	return items
+}
+
+// rawNoteStatsRow 从笔记数据页读取的一篇笔记
+type rawNoteStatsRow struct {
+	Impression string        `json:"impression"`
+	Href       string        `json:"href"`
+	Title      string        `json:"title"`
+	Time       string        `json:"time"`
+	Stats      []rawNoteStat `json:"stats"`
+}
+
+// readNoteStatsRows 读取笔记数据；表格按表头对应列，卡片按数据项名称
+func readNoteStatsRows(page *rod.Page) []NoteStats {
+	result := page.MustEval(`(selector) => {
+		const rows = [];
+		document.querySelectorAll(selector).forEach((el) => {
+			const text = (s) => { const n = el.querySelector(s); return n ? n.textContent.trim() : ""; };
+			const link = el.querySelector('a[href*="/explore/"], a[href*="/discovery/item/"]');
+			const stats = [];
+			if (el.tagName === 'TR') {
+				const table = el.closest('table');
+				const headers = table ? Array.from(table.querySelectorAll('thead th')).map((th) => th.textContent.trim()) : [];
+				el.querySelectorAll('td').forEach((td, i) => {
+					stats.push({label: headers[i] || "", value: td.textContent.trim()});
+				});
+			} else {
+				el.querySelectorAll('.data-item, .stat').forEach((s) => {
+					const label = s.querySelector('.label, .name');
+					const value = s.querySelector('.value, .num');
+					stats.push({label: label ? label.textContent.trim() : "", value: value ? value.textContent.trim() : ""});
+				});
+			}
+			rows.push({
+				impression: el.getAttribute('data-impression') || "",
+				href: link ? link.getAttribute('href') || "" : "",
+				title: text('.title, .note-title'),
+				time: text('.time, .publish-time'),
+				stats: stats,
+			});
+		});
+		return JSON.stringify(rows);
+	}`, SelectorNoteStatsRows).String()
+
+	var raw []rawNoteStatsRow
+	if err := json.Unmarshal([]byte(result), &raw); err != nil {
+		logrus.Warnf("解析笔记数据失败: %v", err)
+		return []NoteStats{}
+	}
+
+	notes := make([]NoteStats, 0, len(raw))
+	for _, r := range raw {
+		if note := buildNoteStats(r); note.NoteID != "" || note.Title != "" {
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// buildNoteStats 把笔记数据页的一行转换为 NoteStats
+func buildNoteStats(raw rawNoteStatsRow) NoteStats {
+	note := NoteStats{
+		Title:       raw.Title,
+		PublishTime: strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(raw.Time), "发布于")),
+	}
+	if m := impressionNoteIDPattern.FindStringSubmatch(raw.Impression); m != nil {
+		note.NoteID = m[1]
+	} else {
+		note.NoteID, _ = parseNoteLink(raw.Href)
+	}
+
+	for _, stat := range raw.Stats {
+		applyNoteStat(&note, stat.Label, stat.Value)
+	}
+	return note
+}
+
+// applyAccountStat 按数据项名称填入账号数据，无法识别的名称忽略
+func applyAccountStat(a *AccountStats, label, value string) {
+	label = strings.Join(strings.Fields(label), "")
+	switch {
+	case strings.Contains(label, "新增关注"), strings.Contains(label, "新增粉丝"):
+		a.NewFollowers = xhsutil.ParseCount(value)
+	case strings.Contains(label, "取消关注"), strings.Contains(label, "流失粉丝"):
+		a.LostFollowers = xhsutil.ParseCount(value)
+	case strings.Contains(label, "净涨粉"), strings.Contains(label, "净增粉丝"):
+		a.NetFollowers = parseSignedCount(value)
+	case strings.Contains(label, "总粉丝"), label == "粉丝数":
+		a.TotalFollowers = xhsutil.ParseCount(value)
+	case strings.Contains(label, "主页访客"):
+		a.ProfileVisitors = xhsutil.ParseCount(value)
+	case strings.Contains(label, "点击率"):
+		a.CoverClickRate = parseRate(value)
+	case strings.Contains(label, "完播率"):
+		a.CompletionRate = parseRate(value)
+	case strings.Contains(label, "平均观看时长"), strings.Contains(label, "人均观看时长"):
+		a.AvgViewSeconds = parseSeconds(value)
+	case strings.Contains(label, "时长"):
+		// 观看总时长不记录
+	case strings.Contains(label, "曝光"):
+		a.Impressions = xhsutil.ParseCount(value)
+	case strings.Contains(label, "观看"), strings.Contains(label, "阅读"):
+		a.Views = xhsutil.ParseCount(value)
+	case strings.Contains(label, "点赞"):
+		a.Likes = xhsutil.ParseCount(value)
+	case strings.Contains(label, "评论"):
+		a.Comments = xhsutil.ParseCount(value)
+	case strings.Contains(label, "收藏"):
+		a.Collects = xhsutil.ParseCount(value)
+	case strings.Contains(label, "分享"):
+		a.Shares = xhsutil.ParseCount(value)
+	}
+}
+
+// applyNoteStat 按表头名称填入笔记数据，无法识别的名称忽略
+func applyNoteStat(n *NoteStats, label, value string) {
+	label = strings.Join(strings.Fields(label), "")
+	switch {
+	case strings.Contains(label, "涨粉"):
+		n.NewFollowers = xhsutil.ParseCount(value)
+	case strings.Contains(label, "点击率"):
+		n.CoverClickRate = parseRate(value)
+	case strings.Contains(label, "完播率"):
+		n.CompletionRate = parseRate(value)
+	case strings.Contains(label, "时长"):
+		n.AvgViewSeconds = parseSeconds(value)
+	case strings.Contains(label, "曝光"):
+		n.Impressions = xhsutil.ParseCount(value)
+	case strings.Contains(label, "观看"), strings.Contains(label, "阅读"):
+		n.Views = xhsutil.ParseCount(value)
+	case strings.Contains(label, "点赞"):
+		n.Likes = xhsutil.ParseCount(value)
+	case strings.Contains(label, "评论"):
+		n.Comments = xhsutil.ParseCount(value)
+	case strings.Contains(label, "收藏"):
+		n.Collects = xhsutil.ParseCount(value)
+	case strings.Contains(label, "分享"):
+		n.Shares = xhsutil.ParseCount(value)
+	}
+}
+
+// parseSignedCount 解析可能为负数的计数，如净涨粉 "-3"
+func parseSignedCount(s string) int64 {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		return -xhsutil.ParseCount(rest)
+	}
+	return xhsutil.ParseCount(strings.TrimPrefix(s, "+"))
+}
+
+// parseRate 解析百分比文本，如 "12.3%"，返回 12.3；无法识别时返回 0
+func parseRate(s string) float64 {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// durationPartPattern 时长文本中的一段，如 "1分"、"23秒"、"12.5s"
+var durationPartPattern = regexp.MustCompile(`([0-9.]+)\s*(小时|分钟|分|秒|h|m|s)`)
+
+// parseSeconds 解析时长文本为秒，支持 "1分23秒"、"12.5s"、"01:23"；无法识别时返回 0
+func parseSeconds(s string) float64 {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+
+	if strings.Contains(s, ":") {
+		var total float64
+		for _, part := range strings.Split(s, ":") {
+			v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return 0
+			}
+			total = total*60 + v
+		}
+		return total
+	}
+
+	matches := durationPartPattern.FindAllStringSubmatch(s, -1)
+	if matches == nil {
+		v, _ := strconv.ParseFloat(s, 64)
+		return v
+	}
+	var total float64
+	for _, m := range matches {
+		v, _ := strconv.ParseFloat(m[1], 64)
+		switch m[2] {
+		case "小时", "h":
+			total += v * 3600
+		case "分钟", "分", "m":
+			total += v * 60
+		default:
+			total += v
+		}
+	}
+	return total
+}
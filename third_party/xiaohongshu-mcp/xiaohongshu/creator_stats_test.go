@@ -0,0 +1,110 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyAccountStat(t *testing.T) {
+	var got AccountStats
+	for _, item := range []rawNoteStat{
+		{Label: "曝光数", Value: "1.2万"},
+		{Label: "观看数", Value: "3,456"},
+		{Label: "封面点击率", Value: "8.5%"},
+		{Label: "平均观看时长", Value: "1分05秒"},
+		{Label: "观看总时长", Value: "20小时"},
+		{Label: "视频完播率", Value: "23.4%"},
+		{Label: "点赞数", Value: "210"},
+		{Label: "收藏数", Value: "35"},
+		{Label: "新增关注", Value: "18"},
+		{Label: "取消关注", Value: "3"},
+		{Label: "净涨粉", Value: "-2"},
+		{Label: "总粉丝数", Value: "1,024"},
+		{Label: "主页访客", Value: "99"},
+		{Label: "未知指标", Value: "7"},
+	} {
+		applyAccountStat(&got, item.Label, item.Value)
+	}
+
+	assert.Equal(t, AccountStats{
+		Impressions:     12000,
+		Views:           3456,
+		CoverClickRate:  8.5,
+		AvgViewSeconds:  65,
+		CompletionRate:  23.4,
+		Likes:           210,
+		Collects:        35,
+		NewFollowers:    18,
+		LostFollowers:   3,
+		NetFollowers:    -2,
+		TotalFollowers:  1024,
+		ProfileVisitors: 99,
+	}, got)
+}
+
+func TestBuildNoteStats(t *testing.T) {
+	got := buildNoteStats(rawNoteStatsRow{
+		Href:  "/explore/n1?xsec_token=t",
+		Title: "散步",
+		Time:  "发布于 2025-03-01 10:21",
+		Stats: []rawNoteStat{
+			{Label: "笔记", Value: "散步"},
+			{Label: "曝光", Value: "5000"},
+			{Label: "观看", Value: "800"},
+			{Label: "封面点击率", Value: "16%"},
+			{Label: "人均观看时长", Value: "12.5s"},
+			{Label: "点赞", Value: "40"},
+			{Label: "评论", Value: "6"},
+			{Label: "收藏", Value: "9"},
+			{Label: "分享", Value: "2"},
+			{Label: "涨粉", Value: "3"},
+		},
+	})
+
+	assert.Equal(t, NoteStats{
+		NoteID:         "n1",
+		Title:          "散步",
+		PublishTime:    "2025-03-01 10:21",
+		Impressions:    5000,
+		Views:          800,
+		CoverClickRate: 16,
+		AvgViewSeconds: 12.5,
+		Likes:          40,
+		Comments:       6,
+		Collects:       9,
+		Shares:         2,
+		NewFollowers:   3,
+	}, got)
+}
+
+func TestParseSeconds(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{input: "", want: 0},
+		{input: "23秒", want: 23},
+		{input: "1分23秒", want: 83},
+		{input: "2分钟", want: 120},
+		{input: "1小时2分", want: 3720},
+		{input: "12.5s", want: 12.5},
+		{input: "01:23", want: 83},
+		{input: "1:00:05", want: 3605},
+		{input: "30", want: 30},
+		{input: "--", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseSeconds(tt.input))
+		})
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	assert.Equal(t, 12.3, parseRate("12.3%"))
+	assert.Equal(t, 0.0, parseRate("--"))
+	assert.Equal(t, int64(-5), parseSignedCount("-5"))
+	assert.Equal(t, int64(5), parseSignedCount("+5"))
+}
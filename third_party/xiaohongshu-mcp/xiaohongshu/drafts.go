@@ -0,0 +1,254 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SelectorDraftItems 草稿箱中的草稿
+	SelectorDraftItems = ".draft-list .draft-item, .draft-container .draft-item, .drafts .draft-card"
+)
+
+// DraftKind 草稿类型
+type DraftKind string
+
+const (
+	DraftKindImage DraftKind = "image" // 图文
+	DraftKindVideo DraftKind = "video" // 视频
+)
+
+// ParseDraftKind 解析草稿类型，为空时默认为图文
+func ParseDraftKind(s string) (DraftKind, error) {
+	switch DraftKind(strings.TrimSpace(s)) {
+	case "", DraftKindImage:
+		return DraftKindImage, nil
+	case DraftKindVideo:
+		return DraftKindVideo, nil
+	default:
+		return "", fmt.Errorf("未知的草稿类型: %q，可选 image、video", s)
+	}
+}
+
+// tabName 草稿箱中对应的标签名称
+func (k DraftKind) tabName() string {
+	if k == DraftKindVideo {
+		return "视频笔记"
+	}
+	return "图文笔记"
+}
+
+// Draft 草稿箱中的一篇草稿
+// 草稿没有稳定的 ID，按草稿箱中的位置 Index 引用，操作时用标题核对
+type Draft struct {
+	Kind    DraftKind `json:"kind"`
+	Index   int       `json:"index"`
+	Title   string    `json:"title"`
+	Content string    `json:"content,omitempty"` // 正文摘要
+	Cover   string    `json:"cover,omitempty"`
+	SavedAt string    `json:"savedAt,omitempty"` // 页面显示的保存时间
+}
+
+// DraftAction 管理创作中心草稿箱
+type DraftAction struct {
+	page *rod.Page
+}
+
+func NewDraftAction(page *rod.Page) *DraftAction {
+	return &DraftAction{page: page}
+}
+
+// ListDrafts 返回草稿箱中某一类型的草稿，按页面顺序（最近保存的在前）
+func (a *DraftAction) ListDrafts(ctx context.Context, kind DraftKind) ([]Draft, error) {
+	page := a.page.Context(ctx).Timeout(2 * time.Minute)
+
+	if err := openDraftBox(page, kind); err != nil {
+		return nil, err
+	}
+	return readDrafts(page, kind), nil
+}
+
+// PublishDraft 打开草稿并直接发布
+func (a *DraftAction) PublishDraft(ctx context.Context, kind DraftKind, index int, title string) error {
+	// 视频草稿可能需要重新处理，不继承外部 context 的超时
+	page := a.page.Timeout(10 * time.Minute)
+
+	if _, err := clickDraftOperation(page, kind, index, title, "编辑"); err != nil {
+		return err
+	}
+	if err := page.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待草稿编辑页稳定出现问题: %v，继续尝试", err)
+	}
+
+	btn, err := waitForPublishButtonClickable(page)
+	if err != nil {
+		return err
+	}
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击发布按钮失败: %w", err)
+	}
+	if err := waitPublishSuccess(page); err != nil {
+		return err
+	}
+
+	logrus.Infof("已发布草稿: %s", title)
+	return nil
+}
+
+// DeleteDraft 从草稿箱删除草稿
+func (a *DraftAction) DeleteDraft(ctx context.Context, kind DraftKind, index int, title string) error {
+	page := a.page.Context(ctx).Timeout(2 * time.Minute)
+
+	before, err := clickDraftOperation(page, kind, index, title, "删除")
+	if err != nil {
+		return err
+	}
+	if err := confirmDialog(page, "确定", "确认", "删除"); err != nil {
+		return err
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	if after := readDrafts(page, kind); len(after) >= len(before) {
+		return fmt.Errorf("删除草稿 %q 后草稿数量未减少，可能删除失败", title)
+	}
+	logrus.Infof("已删除草稿: %s", title)
+	return nil
+}
+
+// openDraftBox 在发布页打开草稿箱并切换到对应类型
+func openDraftBox(page *rod.Page, kind DraftKind) error {
+	logrus.Infof("打开草稿箱: %s", kind.tabName())
+	if err := page.Navigate(urlOfPublic); err != nil {
+		return fmt.Errorf("打开发布页失败: %w", err)
+	}
+	if err := page.WaitLoad(); err != nil {
+		logrus.Warnf("等待发布页加载出现问题: %v，继续尝试", err)
+	}
+	if err := page.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待 DOM 稳定出现问题: %v，继续尝试", err)
+	}
+
+	entry, err := page.Timeout(10*time.Second).ElementR("button, div, span", `^\s*草稿箱`)
+	if err != nil {
+		return fmt.Errorf("未找到草稿箱入口: %w", err)
+	}
+	if err := entry.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击草稿箱失败: %w", err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	if tab, err := page.Timeout(3*time.Second).ElementR("div, span", "^\\s*"+kind.tabName()); err == nil {
+		if err := tab.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return fmt.Errorf("切换到%s失败: %w", kind.tabName(), err)
+		}
+		sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+	}
+
+	if _, err := page.Timeout(5 * time.Second).Element(SelectorDraftItems); err != nil {
+		// 草稿箱为空时没有草稿条目
+		logrus.Infof("%s草稿箱为空或未加载: %v", kind.tabName(), err)
+	}
+	return nil
+}
+
+// clickDraftOperation 打开草稿箱，核对草稿后点击其上的操作（编辑、删除）
+// 返回点击前草稿箱中的草稿
+func clickDraftOperation(page *rod.Page, kind DraftKind, index int, title, name string) ([]Draft, error) {
+	if err := openDraftBox(page, kind); err != nil {
+		return nil, err
+	}
+	drafts := readDrafts(page, kind)
+	if err := checkDraft(drafts, index, title); err != nil {
+		return nil, err
+	}
+
+	items, err := page.Elements(SelectorDraftItems)
+	if err != nil || index >= len(items) {
+		return nil, fmt.Errorf("草稿箱已变化，请重新读取草稿列表")
+	}
+	item := items[index]
+	if err := item.Hover(); err != nil {
+		return nil, fmt.Errorf("悬停草稿失败: %w", err)
+	}
+	sleepRandom(hoverTimeRange.min, hoverTimeRange.max)
+
+	op, err := item.ElementR("button, span, div", "^\\s*"+name+"\\s*$")
+	if err != nil {
+		return nil, fmt.Errorf("未找到草稿操作 %s: %w", name, err)
+	}
+	if err := op.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, fmt.Errorf("点击草稿操作 %s 失败: %w", name, err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+	return drafts, nil
+}
+
+// rawDraft 从草稿箱读取的原始草稿
+type rawDraft struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Cover   string `json:"cover"`
+	Time    string `json:"time"`
+}
+
+func readDrafts(page *rod.Page, kind DraftKind) []Draft {
+	result := page.MustEval(`(selector) => {
+		const items = [];
+		document.querySelectorAll(selector).forEach((el) => {
+			const text = (s) => { const n = el.querySelector(s); return n ? n.textContent.trim() : ""; };
+			const cover = el.querySelector('img');
+			items.push({
+				title: text('.title, .draft-title'),
+				content: text('.content, .desc, .draft-content'),
+				cover: cover ? cover.getAttribute('src') || "" : "",
+				time: text('.time, .date, .save-time'),
+			});
+		});
+		return JSON.stringify(items);
+	}`, SelectorDraftItems).String()
+
+	var raw []rawDraft
+	if err := json.Unmarshal([]byte(result), &raw); err != nil {
+		logrus.Warnf("解析草稿箱失败: %v", err)
+		return []Draft{}
+	}
+	return buildDrafts(raw, kind)
+}
+
+// buildDrafts 按页面顺序编号
+func buildDrafts(raw []rawDraft, kind DraftKind) []Draft {
+	drafts := make([]Draft, 0, len(raw))
+	for i, r := range raw {
+		savedAt := strings.TrimSpace(r.Time)
+		for _, prefix := range []string{"保存于", "最后编辑于", "更新于"} {
+			savedAt = strings.TrimSpace(strings.TrimPrefix(savedAt, prefix))
+		}
+		drafts = append(drafts, Draft{
+			Kind:    kind,
+			Index:   i,
+			Title:   r.Title,
+			Content: r.Content,
+			Cover:   r.Cover,
+			SavedAt: savedAt,
+		})
+	}
+	return drafts
+}
+
+// checkDraft 核对 index 处的草稿标题，避免草稿箱变化后操作到别的草稿
+func checkDraft(drafts []Draft, index int, title string) error {
+	if index < 0 || index >= len(drafts) {
+		return fmt.Errorf("草稿箱中没有第 %d 篇草稿（共 %d 篇），请重新读取草稿列表", index, len(drafts))
+	}
+	if got := drafts[index].Title; strings.TrimSpace(got) != strings.TrimSpace(title) {
+		return fmt.Errorf("第 %d 篇草稿的标题是 %q，不是 %q，草稿箱可能已变化，请重新读取草稿列表", index, got, title)
+	}
+	return nil
+}
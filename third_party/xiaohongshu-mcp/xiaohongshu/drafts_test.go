@@ -0,0 +1,42 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDraftKind(t *testing.T) {
+	kind, err := ParseDraftKind("")
+	require.NoError(t, err)
+	assert.Equal(t, DraftKindImage, kind)
+
+	kind, err = ParseDraftKind(" video ")
+	require.NoError(t, err)
+	assert.Equal(t, DraftKindVideo, kind)
+
+	_, err = ParseDraftKind("article")
+	assert.Error(t, err)
+}
+
+func TestBuildDrafts(t *testing.T) {
+	drafts := buildDrafts([]rawDraft{
+		{Title: "晒太阳", Content: "今天去公园", Time: "保存于 2025-03-10 15:30"},
+		{Title: "散步", Time: " 最后编辑于 昨天 10:21"},
+	}, DraftKindImage)
+
+	assert.Equal(t, []Draft{
+		{Kind: DraftKindImage, Index: 0, Title: "晒太阳", Content: "今天去公园", SavedAt: "2025-03-10 15:30"},
+		{Kind: DraftKindImage, Index: 1, Title: "散步", SavedAt: "昨天 10:21"},
+	}, drafts)
+}
+
+func TestCheckDraft(t *testing.T) {
+	drafts := buildDrafts([]rawDraft{{Title: "晒太阳"}, {Title: "散步"}}, DraftKindImage)
+
+	assert.NoError(t, checkDraft(drafts, 1, " 散步"))
+	assert.Error(t, checkDraft(drafts, 0, "散步"), "title mismatch")
+	assert.Error(t, checkDraft(drafts, 2, "散步"), "out of range")
+	assert.Error(t, checkDraft(drafts, -1, "晒太阳"))
+}
@@ -0,0 +1,247 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SelectorEmojiButton 评论框旁打开表情面板的按钮
+	SelectorEmojiButton = "div.input-box .emoji-btn, div.input-box .emoji-icon, div.bottom .emoji-btn, div.bottom [class*=\"emoji\"] svg"
+	// SelectorEmojiItems 表情面板中的表情
+	SelectorEmojiItems = ".emoji-panel img, .emoji-list img, .emoji-container img"
+
+	// emojiCacheTTL 表情列表缓存时间，平台表情很少变化
+	emojiCacheTTL = 6 * time.Hour
+)
+
+// emojiTokenPattern 评论内容中的表情写法，与平台一致，如 [笑哭R]
+var emojiTokenPattern = regexp.MustCompile(`\[[^\[\]\s]{1,10}R\]`)
+
+var emojiCache struct {
+	mu     sync.Mutex
+	names  []string
+	loaded time.Time
+}
+
+// EmojiAction 读取评论表情面板
+type EmojiAction struct {
+	page *rod.Page
+}
+
+func NewEmojiAction(page *rod.Page) *EmojiAction {
+	return &EmojiAction{page: page}
+}
+
+// CachedEmojis 返回缓存中的表情名称，缓存为空或过期时返回 false
+func CachedEmojis() ([]string, bool) {
+	emojiCache.mu.Lock()
+	defer emojiCache.mu.Unlock()
+
+	if len(emojiCache.names) == 0 || time.Since(emojiCache.loaded) > emojiCacheTTL {
+		return nil, false
+	}
+	return append([]string(nil), emojiCache.names...), true
+}
+
+func storeEmojis(names []string) {
+	emojiCache.mu.Lock()
+	defer emojiCache.mu.Unlock()
+
+	emojiCache.names = append([]string(nil), names...)
+	emojiCache.loaded = time.Now()
+}
+
+// ListEmojis 打开任意一篇笔记的评论框和表情面板，读取可用的表情名称
+func (e *EmojiAction) ListEmojis(ctx context.Context, feedID, xsecToken string) ([]string, error) {
+	page := e.page.Timeout(60 * time.Second)
+
+	url := makeFeedDetailURL(feedID, xsecToken)
+	logrus.Infof("打开 feed 详情页读取表情: %s", url)
+
+	if err := openFeedDetailPage(page, url); err != nil {
+		return nil, err
+	}
+	if _, err := activateCommentInput(page); err != nil {
+		return nil, err
+	}
+	if err := openEmojiPanel(page); err != nil {
+		return nil, err
+	}
+
+	names := normalizeEmojiNames(readEmojiNames(page))
+	if len(names) == 0 {
+		return nil, fmt.Errorf("表情面板中没有读取到表情")
+	}
+
+	storeEmojis(names)
+	return names, nil
+}
+
+// activateCommentInput 点击评论框占位文字，返回可输入的评论编辑区
+func activateCommentInput(page *rod.Page) (*rod.Element, error) {
+	elem, err := page.Element("div.input-box div.content-edit span")
+	if err != nil {
+		return nil, fmt.Errorf("未找到评论输入框，该帖子可能不支持评论或网页端不可访问: %w", err)
+	}
+	if err := elem.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, fmt.Errorf("无法点击评论输入框: %w", err)
+	}
+
+	editor, err := page.Element("div.input-box div.content-edit p.content-input")
+	if err != nil {
+		return nil, fmt.Errorf("未找到评论输入区域: %w", err)
+	}
+	return editor, nil
+}
+
+// inputCommentContent 输入评论内容，内容中的 [名称R] 通过表情面板插入
+// 没有表情写法时与直接输入相同；面板中找不到的表情按原文本输入
+func inputCommentContent(editor *rod.Element, content string) error {
+	segments := splitEmojiTokens(content)
+	if !hasEmojiSegment(segments) {
+		return editor.Input(content)
+	}
+
+	page := editor.Page()
+	for _, seg := range segments {
+		if seg.Emoji == "" {
+			if err := editor.Input(seg.Text); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := insertEmoji(page, seg.Emoji); err != nil {
+			logrus.Warnf("插入表情 %s 失败，按文本输入: %v", seg.Emoji, err)
+			if err := editor.Input(seg.Emoji); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 再点一次表情按钮收起面板
+	if btn, err := page.Timeout(time.Second).Element(SelectorEmojiButton); err == nil {
+		_ = btn.Click(proto.InputMouseButtonLeft, 1)
+	}
+	return nil
+}
+
+func openEmojiPanel(page *rod.Page) error {
+	if has, _, err := page.Has(SelectorEmojiItems); err == nil && has {
+		return nil
+	}
+
+	btn, err := page.Timeout(3 * time.Second).Element(SelectorEmojiButton)
+	if err != nil {
+		return fmt.Errorf("未找到表情按钮: %w", err)
+	}
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击表情按钮失败: %w", err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	if _, err := page.Timeout(3 * time.Second).Element(SelectorEmojiItems); err != nil {
+		return fmt.Errorf("表情面板未打开: %w", err)
+	}
+	return nil
+}
+
+func insertEmoji(page *rod.Page, name string) error {
+	if err := openEmojiPanel(page); err != nil {
+		return err
+	}
+
+	items, err := page.Elements(SelectorEmojiItems)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if emojiItemName(item) != name {
+			continue
+		}
+		if err := item.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return fmt.Errorf("点击表情失败: %w", err)
+		}
+		time.Sleep(300 * time.Millisecond)
+		return nil
+	}
+	return fmt.Errorf("表情面板中没有 %s", name)
+}
+
+func readEmojiNames(page *rod.Page) []string {
+	items, err := page.Elements(SelectorEmojiItems)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, emojiItemName(item))
+	}
+	return names
+}
+
+// emojiItemName 表情图片的名称，平台放在 alt 或 title 上
+func emojiItemName(item *rod.Element) string {
+	for _, attr := range []string{"alt", "title", "data-name"} {
+		if v, err := item.Attribute(attr); err == nil && v != nil && strings.TrimSpace(*v) != "" {
+			return strings.TrimSpace(*v)
+		}
+	}
+	return ""
+}
+
+// normalizeEmojiNames 只保留 [名称R] 形式的名称并去重
+func normalizeEmojiNames(raw []string) []string {
+	seen := make(map[string]bool, len(raw))
+	var names []string
+	for _, name := range raw {
+		name = strings.TrimSpace(name)
+		if name == "" || emojiTokenPattern.FindString(name) != name || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// contentSegment 评论内容的一段，Emoji 非空时表示一个表情
+type contentSegment struct {
+	Text  string
+	Emoji string
+}
+
+// splitEmojiTokens 把内容拆成文本段和表情段
+func splitEmojiTokens(content string) []contentSegment {
+	var segments []contentSegment
+	last := 0
+	for _, loc := range emojiTokenPattern.FindAllStringIndex(content, -1) {
+		if loc[0] > last {
+			segments = append(segments, contentSegment{Text: content[last:loc[0]]})
+		}
+		segments = append(segments, contentSegment{Emoji: content[loc[0]:loc[1]]})
+		last = loc[1]
+	}
+	if last < len(content) {
+		segments = append(segments, contentSegment{Text: content[last:]})
+	}
+	return segments
+}
+
+func hasEmojiSegment(segments []contentSegment) bool {
+	for _, seg := range segments {
+		if seg.Emoji != "" {
+			return true
+		}
+	}
+	return false
+}
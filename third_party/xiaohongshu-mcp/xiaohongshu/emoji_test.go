@@ -0,0 +1,52 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitEmojiTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []contentSegment
+	}{
+		{
+			name:    "plain text",
+			content: "好可爱",
+			want:    []contentSegment{{Text: "好可爱"}},
+		},
+		{
+			name:    "emoji in the middle",
+			content: "太好笑了[笑哭R]哈哈",
+			want:    []contentSegment{{Text: "太好笑了"}, {Emoji: "[笑哭R]"}, {Text: "哈哈"}},
+		},
+		{
+			name:    "adjacent emojis",
+			content: "[派对R][赞R]",
+			want:    []contentSegment{{Emoji: "[派对R]"}, {Emoji: "[赞R]"}},
+		},
+		{
+			name:    "brackets without R stay text",
+			content: "[图片]看这里",
+			want:    []contentSegment{{Text: "[图片]看这里"}},
+		},
+		{
+			name:    "empty",
+			content: "",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitEmojiTokens(tt.content))
+		})
+	}
+}
+
+func TestNormalizeEmojiNames(t *testing.T) {
+	raw := []string{" [笑哭R] ", "[笑哭R]", "", "笑哭", "[图片]", "[赞R]x", "[赞R]"}
+	assert.Equal(t, []string{"[笑哭R]", "[赞R]"}, normalizeEmojiNames(raw))
+}
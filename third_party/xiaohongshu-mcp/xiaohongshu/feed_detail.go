@@ -64,6 +64,8 @@ func DefaultCommentLoadConfig() CommentLoadConfig {
 
 type FeedDetailAction struct {
 	page *rod.Page
+
+	commentsFeed string // ListComments 已在 page 上打开的笔记，下一页从当前滚动位置继续
 }
 
 func NewFeedDetailAction(page *rod.Page) *FeedDetailAction {
@@ -84,6 +86,21 @@ func (f *FeedDetailAction) GetFeedDetailWithConfig(ctx context.Context, feedID,
 	logrus.Infof("配置: 点击更多=%v, 回复阈值=%d, 最大评论数=%d, 滚动速度=%s",
 		config.ClickMoreReplies, config.MaxRepliesThreshold, config.MaxCommentItems, config.ScrollSpeed)
 
+	if err := openFeedDetailPage(page, url); err != nil {
+		return nil, err
+	}
+
+	if loadAllComments {
+		if err := f.loadAllCommentsWithConfig(page, config); err != nil {
+			logrus.Warnf("加载全部评论失败: %v", err)
+		}
+	}
+
+	return f.extractFeedDetail(page, feedID)
+}
+
+// openFeedDetailPage 打开详情页并检查笔记是否可访问
+func openFeedDetailPage(page *rod.Page, url string) error {
 	// 使用retry-go处理页面导航和DOM稳定等待
 	err := retry.Do(
 		func() error {
@@ -100,21 +117,11 @@ func (f *FeedDetailAction) GetFeedDetailWithConfig(ctx context.Context, feedID,
 	)
 	if err != nil {
 		logrus.Errorf("页面导航失败: %v", err)
-		return nil, err
+		return err
 	}
 	sleepRandom(1000, 1000)
 
-	if err := checkPageAccessible(page); err != nil {
-		return nil, err
-	}
-
-	if loadAllComments {
-		if err := f.loadAllCommentsWithConfig(page, config); err != nil {
-			logrus.Warnf("加载全部评论失败: %v", err)
-		}
-	}
-
-	return f.extractFeedDetail(page, feedID)
+	return checkPageAccessible(page)
 }
 
 // ========== 评论加载器 ==========
@@ -856,8 +863,13 @@ func (f *FeedDetailAction) extractFeedDetail(page *rod.Page, feedID string) (*Fe
 		return nil, fmt.Errorf("feed %s not found in noteDetailMap", feedID)
 	}
 
+	note := noteDetail.Note
+	note.Media = BuildNoteMedia(note)
+	note.Mentions = ParseMentions(note.Desc, note.AtUserList)
+	note.Topics = ParseTopics(note.Desc, note.TagList)
+
 	return &FeedDetailResponse{
-		Note:     noteDetail.Note,
+		Note:     note,
 		Comments: noteDetail.Comments,
 	}, nil
 }
@@ -0,0 +1,134 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// 关注按钮选择器
+const (
+	// SelectorProfileFollowButton 用户主页的关注按钮
+	SelectorProfileFollowButton = ".user-info .follow-button, .info-part .follow-button, .user-info button.follow"
+	// SelectorNoteFollowButton 笔记详情页作者旁的关注按钮
+	SelectorNoteFollowButton = ".author-wrapper .follow-button, .author-container .follow-button, .note-detail-follow-btn"
+)
+
+// FollowResult 关注/取消关注的结果
+type FollowResult struct {
+	Followed bool `json:"followed"` // 操作后的关注状态
+	Changed  bool `json:"changed"`  // 是否实际点击改变了状态，已处于目标状态时为 false
+}
+
+// FollowAction 负责关注/取消关注用户
+type FollowAction struct {
+	page *rod.Page
+}
+
+func NewFollowAction(page *rod.Page) *FollowAction {
+	return &FollowAction{page: page}
+}
+
+// FollowUser 在用户主页关注或取消关注该用户，已处于目标状态时不点击
+func (a *FollowAction) FollowUser(ctx context.Context, userID, xsecToken string, follow bool) (*FollowResult, error) {
+	url := makeUserProfileURL(userID, xsecToken)
+	return a.perform(url, SelectorProfileFollowButton, follow)
+}
+
+// FollowNoteAuthor 在笔记详情页关注或取消关注笔记作者，已处于目标状态时不点击
+func (a *FollowAction) FollowNoteAuthor(ctx context.Context, feedID, xsecToken string, follow bool) (*FollowResult, error) {
+	url := makeFeedDetailURL(feedID, xsecToken)
+	return a.perform(url, SelectorNoteFollowButton, follow)
+}
+
+func (a *FollowAction) perform(url, selector string, follow bool) (*FollowResult, error) {
+	actionName := "关注"
+	if !follow {
+		actionName = "取消关注"
+	}
+
+	// 不使用 Context(ctx)，避免继承外部 context 的超时
+	page := a.page.Timeout(60 * time.Second)
+	logrus.Infof("打开页面进行%s: %s", actionName, url)
+
+	if err := openFeedDetailPage(page, url); err != nil {
+		return nil, err
+	}
+
+	btn, err := page.Timeout(5 * time.Second).Element(selector)
+	if err != nil {
+		return nil, fmt.Errorf("未找到关注按钮，可能是自己的主页或页面结构变化: %w", err)
+	}
+
+	followed, err := readFollowState(btn)
+	if err != nil {
+		return nil, err
+	}
+	if followed == follow {
+		logrus.Infof("已处于目标状态 (followed=%v)，跳过%s", followed, actionName)
+		return &FollowResult{Followed: followed}, nil
+	}
+
+	btn.MustScrollIntoView()
+	sleepRandom(humanDelayRange.min, humanDelayRange.max)
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, fmt.Errorf("点击关注按钮失败: %w", err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	// 取消关注会弹出确认框
+	if !follow {
+		if confirm, err := page.Timeout(3*time.Second).ElementR("button, .btn, .confirm", `^\s*(确定|确认|不再关注|取消关注)\s*$`); err == nil {
+			if err := confirm.Click(proto.InputMouseButtonLeft, 1); err != nil {
+				return nil, fmt.Errorf("点击取消关注确认按钮失败: %w", err)
+			}
+		}
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+
+	btn, err = page.Timeout(5 * time.Second).Element(selector)
+	if err != nil {
+		return nil, fmt.Errorf("%s后未找到关注按钮: %w", actionName, err)
+	}
+	followed, err = readFollowState(btn)
+	if err != nil {
+		return nil, err
+	}
+	if followed != follow {
+		return nil, fmt.Errorf("%s失败，点击后关注状态未变化", actionName)
+	}
+
+	logrus.Infof("%s成功", actionName)
+	return &FollowResult{Followed: followed, Changed: true}, nil
+}
+
+func readFollowState(btn *rod.Element) (bool, error) {
+	text, err := btn.Text()
+	if err != nil {
+		return false, fmt.Errorf("读取关注按钮文字失败: %w", err)
+	}
+	followed, ok := parseFollowState(text)
+	if !ok {
+		return false, fmt.Errorf("无法识别关注按钮状态: %q", text)
+	}
+	return followed, nil
+}
+
+// parseFollowState 根据关注按钮文字判断是否已关注
+func parseFollowState(text string) (followed bool, ok bool) {
+	text = strings.Join(strings.Fields(text), "")
+	switch {
+	case strings.Contains(text, "已关注"), strings.Contains(text, "互相关注"), strings.Contains(text, "已互关"):
+		return true, true
+	case strings.Contains(text, "回关"), strings.Contains(text, "关注"):
+		return false, true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,287 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+const (
+	defaultFollowPageSize = 20
+	maxFollowPageSize     = 100
+	// followListMaxScrolls 单次分页最多滚动次数
+	followListMaxScrolls = 40
+	// followListStagnantLimit 列表长度连续多少次未增加即认为已到底
+	followListStagnantLimit = 4
+
+	// SelectorFollowListItems 粉丝/关注弹窗中的用户条目
+	SelectorFollowListItems = ".follow-list .user-item, .fans-list .user-item, .reds-modal .user-item"
+)
+
+// FollowListKind 用户列表类型
+type FollowListKind string
+
+const (
+	FollowListFollowers  FollowListKind = "followers"  // 粉丝
+	FollowListFollowings FollowListKind = "followings" // 关注
+)
+
+// tabName 主页上对应的互动项名称
+func (k FollowListKind) tabName() string {
+	if k == FollowListFollowers {
+		return "粉丝"
+	}
+	return "关注"
+}
+
+// FollowUserItem 粉丝/关注列表中的一个用户
+type FollowUserItem struct {
+	UserID    string `json:"userId"`
+	Nickname  string `json:"nickname"`
+	Avatar    string `json:"avatar,omitempty"`
+	Desc      string `json:"desc,omitempty"`
+	XsecToken string `json:"xsecToken,omitempty"`
+	Mutual    bool   `json:"mutual"` // 是否互相关注
+}
+
+// FollowUserPage 一页粉丝或关注
+type FollowUserPage struct {
+	Kind    FollowListKind   `json:"kind"`
+	Users   []FollowUserItem `json:"users"`
+	Cursor  string           `json:"cursor"`  // 下一页游标，传给下次请求的 cursor 参数
+	HasMore bool             `json:"hasMore"` // 是否还有更多用户
+}
+
+// FollowListAction 读取当前登录账号的粉丝和关注列表
+type FollowListAction struct {
+	page *rod.Page
+
+	openKind FollowListKind // page 上已打开的列表弹窗，下一页从当前滚动位置继续
+}
+
+func NewFollowListAction(page *rod.Page) *FollowListAction {
+	return &FollowListAction{page: page}
+}
+
+// ListFollowers 返回 cursor 之后的一页粉丝，列表按平台顺序（最新关注的在前）
+func (a *FollowListAction) ListFollowers(ctx context.Context, cursor string, limit int) (*FollowUserPage, error) {
+	return a.list(ctx, FollowListFollowers, cursor, limit)
+}
+
+// ListFollowings 返回 cursor 之后的一页关注
+func (a *FollowListAction) ListFollowings(ctx context.Context, cursor string, limit int) (*FollowUserPage, error) {
+	return a.list(ctx, FollowListFollowings, cursor, limit)
+}
+
+// list 在个人主页打开粉丝/关注弹窗，滚动到足够返回本页为止
+// cursor 为上一页最后一个用户的 ID，为空表示从头开始；
+// 弹窗已在上一页打开时不重新打开，从当前滚动位置继续加载
+func (a *FollowListAction) list(ctx context.Context, kind FollowListKind, cursor string, limit int) (*FollowUserPage, error) {
+	if limit <= 0 {
+		limit = defaultFollowPageSize
+	}
+	if limit > maxFollowPageSize {
+		limit = maxFollowPageSize
+	}
+
+	page := a.page.Context(ctx).Timeout(3 * time.Minute)
+	resume := cursor != "" && a.openKind == kind
+	logrus.Infof("读取%s列表: cursor=%q, limit=%d, resume=%v", kind.tabName(), cursor, limit, resume)
+
+	if !resume {
+		a.openKind = ""
+		if err := NewNavigate(page).ToProfilePage(ctx); err != nil {
+			return nil, fmt.Errorf("打开个人主页失败: %w", err)
+		}
+		page.MustWaitStable()
+
+		if err := openFollowListDialog(page, kind); err != nil {
+			return nil, err
+		}
+		a.openKind = kind
+	}
+
+	users := readFollowListItems(page)
+	stagnant := 0
+	for i := 0; i < followListMaxScrolls && !followPageReady(users, cursor, limit); i++ {
+		lastCount := len(users)
+
+		scrollFollowList(page)
+		sleepRandom(postScrollRange.min, postScrollRange.max)
+		users = readFollowListItems(page)
+
+		if len(users) > lastCount {
+			stagnant = 0
+			continue
+		}
+		stagnant++
+		if stagnant >= followListStagnantLimit {
+			logrus.Infof("%s列表无法继续加载，当前 %d 人", kind.tabName(), len(users))
+			break
+		}
+	}
+
+	list, next, hasMore, found := sliceFollowPage(users, cursor, limit)
+	if !found && stagnant < followListStagnantLimit {
+		// 列表还能继续加载，只是滚动次数用完了
+		return nil, fmt.Errorf("%w: 已加载 %d 人仍未到达游标 %s", errors.ErrCursorTooDeep, len(users), cursor)
+	}
+	if !found {
+		return nil, fmt.Errorf("未找到游标 %s 对应的用户，对方可能已取消关注，请从头读取", cursor)
+	}
+
+	return &FollowUserPage{
+		Kind:    kind,
+		Users:   list,
+		Cursor:  next,
+		HasMore: hasMore || stagnant < followListStagnantLimit,
+	}, nil
+}
+
+// openFollowListDialog 点击主页上的 "关注" 或 "粉丝" 打开用户列表弹窗
+func openFollowListDialog(page *rod.Page, kind FollowListKind) error {
+	tab, err := page.Timeout(5*time.Second).ElementR(".user-interactions > div", kind.tabName())
+	if err != nil {
+		return fmt.Errorf("未找到主页上的%s入口: %w", kind.tabName(), err)
+	}
+	if err := tab.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击%s入口失败: %w", kind.tabName(), err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	if _, err := page.Timeout(10 * time.Second).Element(SelectorFollowListItems); err != nil {
+		// 列表为空时弹窗里没有用户条目
+		logrus.Warnf("%s列表为空或未加载: %v", kind.tabName(), err)
+	}
+	return nil
+}
+
+func scrollFollowList(page *rod.Page) {
+	page.MustEval(`(selector) => {
+		const items = document.querySelectorAll(selector);
+		if (items.length > 0) {
+			items[items.length - 1].scrollIntoView({behavior: "smooth", block: "end"});
+		}
+	}`, SelectorFollowListItems)
+}
+
+// rawFollowItem 从页面读取的原始条目
+type rawFollowItem struct {
+	Href     string `json:"href"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+	Desc     string `json:"desc"`
+	Button   string `json:"button"`
+}
+
+// readFollowListItems 读取弹窗中已加载的用户
+func readFollowListItems(page *rod.Page) []FollowUserItem {
+	result := page.MustEval(`(selector) => {
+		const items = [];
+		document.querySelectorAll(selector).forEach((el) => {
+			const link = el.querySelector('a[href*="/user/profile/"]');
+			const name = el.querySelector('.name, .user-name, .nickname');
+			const avatar = el.querySelector('img');
+			const desc = el.querySelector('.desc, .user-desc');
+			const button = el.querySelector('button, .follow-button');
+			items.push({
+				href: link ? link.getAttribute('href') || "" : "",
+				nickname: name ? name.textContent.trim() : "",
+				avatar: avatar ? avatar.getAttribute('src') || "" : "",
+				desc: desc ? desc.textContent.trim() : "",
+				button: button ? button.textContent.trim() : "",
+			});
+		});
+		return JSON.stringify(items);
+	}`, SelectorFollowListItems).String()
+
+	var raw []rawFollowItem
+	if err := json.Unmarshal([]byte(result), &raw); err != nil {
+		logrus.Warnf("解析用户列表失败: %v", err)
+		return nil
+	}
+	return buildFollowItems(raw)
+}
+
+// buildFollowItems 解析主页链接中的用户 ID 和 xsec_token，按用户去重
+func buildFollowItems(raw []rawFollowItem) []FollowUserItem {
+	seen := make(map[string]bool, len(raw))
+	users := make([]FollowUserItem, 0, len(raw))
+	for _, r := range raw {
+		userID, xsecToken := parseProfileLink(r.Href)
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		users = append(users, FollowUserItem{
+			UserID:    userID,
+			Nickname:  r.Nickname,
+			Avatar:    r.Avatar,
+			Desc:      r.Desc,
+			XsecToken: xsecToken,
+			Mutual:    isMutualFollow(r.Button),
+		})
+	}
+	return users
+}
+
+// parseProfileLink 从 /user/profile/<userId>?xsec_token=... 中取出用户 ID 和 xsec_token
+func parseProfileLink(href string) (userID, xsecToken string) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", ""
+	}
+	rest, ok := strings.CutPrefix(u.Path, "/user/profile/")
+	if !ok {
+		return "", ""
+	}
+	userID, _, _ = strings.Cut(rest, "/")
+	return userID, u.Query().Get("xsec_token")
+}
+
+// isMutualFollow 根据条目按钮文字判断是否互相关注
+func isMutualFollow(button string) bool {
+	button = strings.Join(strings.Fields(button), "")
+	return strings.Contains(button, "互相关注") || strings.Contains(button, "已互关")
+}
+
+// followPageReady 判断已加载的用户是否足够返回本页
+func followPageReady(users []FollowUserItem, cursor string, limit int) bool {
+	_, _, hasMore, found := sliceFollowPage(users, cursor, limit)
+	return found && hasMore
+}
+
+// sliceFollowPage 取出 cursor 之后的 limit 个用户
+// 返回本页用户、下一页游标、已加载的用户中是否还有剩余，以及 cursor 是否找到
+func sliceFollowPage(users []FollowUserItem, cursor string, limit int) ([]FollowUserItem, string, bool, bool) {
+	start := 0
+	if cursor != "" {
+		start = -1
+		for i, u := range users {
+			if u.UserID == cursor {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, "", false, false
+		}
+	}
+
+	end := min(start+limit, len(users))
+	page := append([]FollowUserItem{}, users[start:end]...)
+
+	next := cursor
+	if len(page) > 0 {
+		next = page[len(page)-1].UserID
+	}
+	return page, next, end < len(users), true
+}
@@ -0,0 +1,62 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProfileLink(t *testing.T) {
+	tests := []struct {
+		href      string
+		userID    string
+		xsecToken string
+	}{
+		{href: "/user/profile/5f1a2b3c?xsec_token=ABC%3D&xsec_source=pc_fans", userID: "5f1a2b3c", xsecToken: "ABC="},
+		{href: "https://www.xiaohongshu.com/user/profile/5f1a2b3c", userID: "5f1a2b3c"},
+		{href: "/explore/64f1a2b3", userID: ""},
+		{href: "", userID: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.href, func(t *testing.T) {
+			userID, xsecToken := parseProfileLink(tt.href)
+			assert.Equal(t, tt.userID, userID)
+			assert.Equal(t, tt.xsecToken, xsecToken)
+		})
+	}
+}
+
+func TestBuildFollowItems(t *testing.T) {
+	raw := []rawFollowItem{
+		{Href: "/user/profile/u1?xsec_token=t1", Nickname: "小红", Button: "回关"},
+		{Href: "/user/profile/u2?xsec_token=t2", Nickname: "小蓝", Button: "互相关注"},
+		{Href: "/user/profile/u1?xsec_token=t1", Nickname: "小红", Button: "回关"},
+		{Href: "", Nickname: "无链接"},
+	}
+
+	users := buildFollowItems(raw)
+	assert.Equal(t, []FollowUserItem{
+		{UserID: "u1", Nickname: "小红", XsecToken: "t1"},
+		{UserID: "u2", Nickname: "小蓝", XsecToken: "t2", Mutual: true},
+	}, users)
+}
+
+func TestSliceFollowPage(t *testing.T) {
+	users := []FollowUserItem{{UserID: "a"}, {UserID: "b"}, {UserID: "c"}}
+
+	page, next, hasMore, found := sliceFollowPage(users, "", 2)
+	assert.True(t, found)
+	assert.Equal(t, []FollowUserItem{{UserID: "a"}, {UserID: "b"}}, page)
+	assert.Equal(t, "b", next)
+	assert.True(t, hasMore)
+
+	page, next, hasMore, found = sliceFollowPage(users, "b", 2)
+	assert.True(t, found)
+	assert.Equal(t, []FollowUserItem{{UserID: "c"}}, page)
+	assert.Equal(t, "c", next)
+	assert.False(t, hasMore)
+
+	_, _, _, found = sliceFollowPage(users, "x", 2)
+	assert.False(t, found)
+}
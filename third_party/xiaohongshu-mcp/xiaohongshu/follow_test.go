@@ -0,0 +1,32 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFollowState(t *testing.T) {
+	tests := []struct {
+		text     string
+		followed bool
+		ok       bool
+	}{
+		{text: "关注", followed: false, ok: true},
+		{text: " + 关注 ", followed: false, ok: true},
+		{text: "回关", followed: false, ok: true},
+		{text: "已关注", followed: true, ok: true},
+		{text: "互相关注", followed: true, ok: true},
+		{text: "已 互关", followed: true, ok: true},
+		{text: "发私信", followed: false, ok: false},
+		{text: "", followed: false, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			followed, ok := parseFollowState(tt.text)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.followed, followed)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// MentionUser 通过 @ 选择弹窗插入的用户
+type MentionUser struct {
+	UserID   string `json:"userId"`
+	Nickname string `json:"nickname"`
+}
+
+// mentionPicker 描述一个编辑框的 @ 选择弹窗
+type mentionPicker struct {
+	items  string // 弹窗中候选用户的选择器
+	entity string // 选中后编辑框内提及实体的选择器
+}
+
+var (
+	// commentMentionPicker 笔记详情页评论框
+	commentMentionPicker = mentionPicker{
+		items:  ".mention-container .mention-item, .at-user-list .user-item, .mention-list .item",
+		entity: ".mention, [data-mention], a[data-user-id]",
+	}
+	// publishMentionPicker 创作者中心发布页正文编辑器
+	publishMentionPicker = mentionPicker{
+		items:  "#creator-editor-mention-container .item, .mention-list .item",
+		entity: ".mention, [data-type=\"mention\"]",
+	}
+)
+
+// mentionPopupTimeout 输入 @ 或关键字后等待候选用户出现的最长时间
+const mentionPopupTimeout = 3 * time.Second
+
+var profileIDPattern = regexp.MustCompile(`/user/profile/([0-9a-zA-Z]+)`)
+
+// insertMentions 在编辑框末尾逐个输入 @ 并从弹窗中选中用户，插入的是真正的提及而不是纯文本
+// 选完后核对编辑框内的提及实体数量，数量不足时返回错误
+func insertMentions(editor *rod.Element, picker mentionPicker, mentions []string) ([]MentionUser, error) {
+	mentions = normalizeMentions(mentions)
+	if len(mentions) == 0 {
+		return nil, nil
+	}
+
+	before := countMentionEntities(editor, picker)
+
+	var inserted []MentionUser
+	for _, mention := range mentions {
+		user, err := insertMention(editor, picker, mention)
+		if err != nil {
+			return inserted, fmt.Errorf("插入提及 @%s 失败: %w", mention, err)
+		}
+		logrus.Infof("已插入提及: @%s (%s)", user.Nickname, user.UserID)
+		inserted = append(inserted, *user)
+	}
+
+	if got := countMentionEntities(editor, picker) - before; got < len(mentions) {
+		return inserted, fmt.Errorf("编辑框中只识别到 %d 个提及，期望 %d 个", got, len(mentions))
+	}
+	return inserted, nil
+}
+
+// insertMention 输入 @ 后先在默认候选（最近联系、关注的人）中查找，找不到再输入关键字搜索
+func insertMention(editor *rod.Element, picker mentionPicker, mention string) (*MentionUser, error) {
+	page := editor.Page()
+
+	if err := editor.Input(" @"); err != nil {
+		return nil, fmt.Errorf("输入@失败: %w", err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	if item, user, ok := findMentionCandidate(page, picker, mention); ok {
+		return user, clickMentionCandidate(item)
+	}
+
+	for _, char := range mention {
+		if err := editor.Input(string(char)); err != nil {
+			return nil, fmt.Errorf("输入字符[%c]失败: %w", char, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	time.Sleep(1 * time.Second)
+
+	item, user, ok := findMentionCandidate(page, picker, mention)
+	if !ok {
+		return nil, fmt.Errorf("@ 弹窗中没有找到用户 %s", mention)
+	}
+	return user, clickMentionCandidate(item)
+}
+
+func clickMentionCandidate(item *rod.Element) error {
+	if err := item.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击候选用户失败: %w", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+// findMentionCandidate 读取弹窗中的候选用户并挑出与 mention 匹配的一个
+func findMentionCandidate(page *rod.Page, picker mentionPicker, mention string) (*rod.Element, *MentionUser, bool) {
+	items, err := page.Timeout(mentionPopupTimeout).Elements(picker.items)
+	if err != nil || len(items) == 0 {
+		logrus.Debugf("未找到 @ 候选用户: %v", err)
+		return nil, nil, false
+	}
+
+	candidates := make([]MentionUser, len(items))
+	for i, item := range items {
+		candidates[i] = readMentionCandidate(item)
+	}
+
+	idx := pickMentionCandidate(candidates, mention)
+	if idx < 0 {
+		return nil, nil, false
+	}
+	return items[idx], &candidates[idx], true
+}
+
+func readMentionCandidate(item *rod.Element) MentionUser {
+	var user MentionUser
+
+	if id, err := item.Attribute("data-user-id"); err == nil && id != nil {
+		user.UserID = *id
+	}
+	if user.UserID == "" {
+		if has, link, err := item.Has(`a[href*="/user/profile/"]`); err == nil && has {
+			if href, err := link.Attribute("href"); err == nil && href != nil {
+				if m := profileIDPattern.FindStringSubmatch(*href); m != nil {
+					user.UserID = m[1]
+				}
+			}
+		}
+	}
+
+	nameEl := item
+	if has, el, err := item.Has(".name, .nickname, .user-name"); err == nil && has {
+		nameEl = el
+	}
+	if text, err := nameEl.Text(); err == nil {
+		user.Nickname = strings.TrimSpace(text)
+	}
+	return user
+}
+
+func countMentionEntities(editor *rod.Element, picker mentionPicker) int {
+	has, _, err := editor.Has(picker.entity)
+	if err != nil || !has {
+		return 0
+	}
+	entities, err := editor.Elements(picker.entity)
+	if err != nil {
+		return 0
+	}
+	return len(entities)
+}
+
+// normalizeMentions 去掉开头的 @ 和空白，按出现顺序去重
+func normalizeMentions(mentions []string) []string {
+	seen := make(map[string]bool, len(mentions))
+	var out []string
+	for _, m := range mentions {
+		m = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(m), "@"))
+		if m == "" || seen[m] {
+			continue
+		}
+		seen[m] = true
+		out = append(out, m)
+	}
+	return out
+}
+
+// pickMentionCandidate 优先按用户 ID 精确匹配，其次按昵称精确匹配（忽略大小写）
+// 不做模糊匹配，避免 @ 错人
+func pickMentionCandidate(candidates []MentionUser, want string) int {
+	for i, c := range candidates {
+		if c.UserID != "" && c.UserID == want {
+			return i
+		}
+	}
+	for i, c := range candidates {
+		if strings.EqualFold(strings.TrimSpace(c.Nickname), want) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,52 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeMentions(t *testing.T) {
+	tests := []struct {
+		name     string
+		mentions []string
+		want     []string
+	}{
+		{name: "nil", mentions: nil, want: nil},
+		{name: "strip at and spaces", mentions: []string{" @小红 ", "@@abc"}, want: []string{"小红", "abc"}},
+		{name: "drop empty", mentions: []string{"", "@", "  "}, want: nil},
+		{name: "dedup keeps order", mentions: []string{"b", "a", "@b"}, want: []string{"b", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeMentions(tt.mentions))
+		})
+	}
+}
+
+func TestPickMentionCandidate(t *testing.T) {
+	candidates := []MentionUser{
+		{UserID: "u1", Nickname: "Alice"},
+		{UserID: "u2", Nickname: "小红"},
+		{UserID: "", Nickname: "u1"},
+	}
+
+	tests := []struct {
+		name string
+		want string
+		idx  int
+	}{
+		{name: "by user id", want: "u2", idx: 1},
+		{name: "user id wins over nickname", want: "u1", idx: 0},
+		{name: "nickname ignores case", want: "alice", idx: 0},
+		{name: "chinese nickname", want: "小红", idx: 1},
+		{name: "no fuzzy match", want: "小", idx: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.idx, pickMentionCandidate(candidates, tt.want))
+		})
+	}
+}
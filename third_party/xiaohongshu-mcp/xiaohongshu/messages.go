@@ -0,0 +1,317 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+)
+
+const (
+	// messagesURL 网页端私信页；未开放私信的账号打开后没有会话列表
+	messagesURL = "https://www.xiaohongshu.com/im"
+
+	defaultMessageLimit = 20
+	maxMessageLimit     = 100
+	// maxMessageLength 单条私信最多字数
+	maxMessageLength = 500
+	// sentMessageTimeout 发送后等待消息出现在会话中的时间
+	sentMessageTimeout = 8 * time.Second
+
+	// SelectorConversationItems 会话列表中的会话
+	SelectorConversationItems = ".conversation-list .conversation-item, .chat-list .chat-item"
+	// SelectorMessageItems 当前会话中的消息
+	SelectorMessageItems = ".message-list .message-item, .chat-content .message-item"
+	// SelectorMessageInput 私信输入框
+	SelectorMessageInput = ".chat-input textarea, .chat-input [contenteditable=\"true\"], .input-area textarea"
+	// SelectorMessageSendButton 私信发送按钮
+	SelectorMessageSendButton = ".chat-input .send-btn, .input-area .send-btn, .chat-input button.submit"
+)
+
+// Conversation 私信会话
+type Conversation struct {
+	UserID      string `json:"userId"`
+	Nickname    string `json:"nickname"`
+	Avatar      string `json:"avatar,omitempty"`
+	LastMessage string `json:"lastMessage"`
+	LastTime    string `json:"lastTime,omitempty"` // 页面显示的时间文字，如 "昨天"、"10:21"
+	Unread      int    `json:"unread"`
+}
+
+// DirectMessage 会话中的一条消息
+type DirectMessage struct {
+	FromMe  bool   `json:"fromMe"`
+	Content string `json:"content"`
+	Time    string `json:"time,omitempty"` // 页面显示的时间分隔文字，可能为空
+}
+
+// ConversationMessages 一个会话最近的消息，按时间从旧到新
+type ConversationMessages struct {
+	UserID   string          `json:"userId"`
+	Nickname string          `json:"nickname"`
+	Messages []DirectMessage `json:"messages"`
+}
+
+// MessageAction 读取和发送私信
+type MessageAction struct {
+	page *rod.Page
+}
+
+func NewMessageAction(page *rod.Page) *MessageAction {
+	return &MessageAction{page: page}
+}
+
+// ListConversations 返回私信会话列表，按页面顺序（最近的在前）
+func (m *MessageAction) ListConversations(ctx context.Context) ([]Conversation, error) {
+	page := m.page.Context(ctx).Timeout(60 * time.Second)
+
+	if err := openMessagesPage(page); err != nil {
+		return nil, err
+	}
+	return readConversations(page), nil
+}
+
+// ReadConversation 打开与 userID 的会话，返回最近 limit 条消息
+func (m *MessageAction) ReadConversation(ctx context.Context, userID string, limit int) (*ConversationMessages, error) {
+	if limit <= 0 {
+		limit = defaultMessageLimit
+	}
+	if limit > maxMessageLimit {
+		limit = maxMessageLimit
+	}
+
+	page := m.page.Context(ctx).Timeout(60 * time.Second)
+
+	conv, err := openConversation(page, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConversationMessages{
+		UserID:   conv.UserID,
+		Nickname: conv.Nickname,
+		Messages: lastMessages(readMessages(page), limit),
+	}, nil
+}
+
+// SendMessage 在与 userID 的已有会话中发送一条文字私信
+// 发送后消息没有出现在会话中时返回 errors.ErrMessageNotSent
+func (m *MessageAction) SendMessage(ctx context.Context, userID, content string) error {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return fmt.Errorf("私信内容不能为空")
+	}
+	if n := len([]rune(content)); n > maxMessageLength {
+		return fmt.Errorf("私信内容过长: %d 字，最多 %d 字", n, maxMessageLength)
+	}
+
+	// 不使用 Context(ctx)，避免继承外部 context 的超时
+	page := m.page.Timeout(2 * time.Minute)
+
+	if _, err := openConversation(page, userID); err != nil {
+		return err
+	}
+	before := readMessages(page)
+
+	input, err := page.Timeout(5 * time.Second).Element(SelectorMessageInput)
+	if err != nil {
+		return fmt.Errorf("未找到私信输入框: %w", err)
+	}
+	if err := input.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击私信输入框失败: %w", err)
+	}
+	sleepRandom(humanDelayRange.min, humanDelayRange.max)
+	if err := input.Input(content); err != nil {
+		return fmt.Errorf("输入私信内容失败: %w", err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	btn, err := page.Timeout(3 * time.Second).Element(SelectorMessageSendButton)
+	if err != nil {
+		return fmt.Errorf("未找到私信发送按钮: %w", err)
+	}
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击私信发送按钮失败: %w", err)
+	}
+
+	deadline := time.Now().Add(sentMessageTimeout)
+	for {
+		time.Sleep(500 * time.Millisecond)
+		if sentMessageAppeared(before, readMessages(page), content) {
+			logrus.Infof("私信已发送给 %s", userID)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.ErrMessageNotSent
+		}
+	}
+}
+
+func openMessagesPage(page *rod.Page) error {
+	logrus.Infof("打开私信页: %s", messagesURL)
+	page.MustNavigate(messagesURL)
+	page.MustWaitDOMStable()
+
+	if _, err := page.Timeout(10 * time.Second).Element(SelectorConversationItems); err != nil {
+		return fmt.Errorf("未找到私信会话列表，网页端可能未开放私信或当前账号没有会话: %w", err)
+	}
+	return nil
+}
+
+// openConversation 在会话列表中点开与 userID 的会话；只能打开已有会话
+func openConversation(page *rod.Page, userID string) (*Conversation, error) {
+	if err := openMessagesPage(page); err != nil {
+		return nil, err
+	}
+
+	conversations := readConversations(page)
+	index := findConversation(conversations, userID)
+	if index < 0 {
+		return nil, fmt.Errorf("没有与用户 %s 的私信会话，只能在已有会话中收发私信", userID)
+	}
+
+	items, err := page.Elements(SelectorConversationItems)
+	if err != nil || index >= len(items) {
+		return nil, fmt.Errorf("会话列表已变化，请重试")
+	}
+	if err := items[index].Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, fmt.Errorf("点击会话失败: %w", err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+
+	if _, err := page.Timeout(10 * time.Second).Element(SelectorMessageItems); err != nil {
+		logrus.Warnf("会话 %s 中没有读取到消息: %v", userID, err)
+	}
+	return &conversations[index], nil
+}
+
+// rawConversation 从页面读取的原始会话
+type rawConversation struct {
+	Href        string `json:"href"`
+	UserID      string `json:"userId"`
+	Nickname    string `json:"nickname"`
+	Avatar      string `json:"avatar"`
+	LastMessage string `json:"lastMessage"`
+	LastTime    string `json:"lastTime"`
+	Unread      string `json:"unread"`
+}
+
+func readConversations(page *rod.Page) []Conversation {
+	result := page.MustEval(`(selector) => {
+		const items = [];
+		document.querySelectorAll(selector).forEach((el) => {
+			const text = (s) => { const n = el.querySelector(s); return n ? n.textContent.trim() : ""; };
+			const link = el.querySelector('a[href*="/user/profile/"]');
+			const avatar = el.querySelector('img');
+			items.push({
+				href: link ? link.getAttribute('href') || "" : "",
+				userId: el.getAttribute('data-user-id') || "",
+				nickname: text('.name, .nickname, .user-name'),
+				avatar: avatar ? avatar.getAttribute('src') || "" : "",
+				lastMessage: text('.last-message, .message-preview, .desc'),
+				lastTime: text('.time, .last-time'),
+				unread: text('.unread, .badge, .red-dot'),
+			});
+		});
+		return JSON.stringify(items);
+	}`, SelectorConversationItems).String()
+
+	var raw []rawConversation
+	if err := json.Unmarshal([]byte(result), &raw); err != nil {
+		logrus.Warnf("解析私信会话失败: %v", err)
+		return nil
+	}
+	return buildConversations(raw)
+}
+
+// buildConversations 补全会话的用户 ID 和未读数；没有用户 ID 的会话（如系统通知）保留但无法打开
+func buildConversations(raw []rawConversation) []Conversation {
+	conversations := make([]Conversation, 0, len(raw))
+	for _, r := range raw {
+		userID := r.UserID
+		if userID == "" {
+			userID, _ = parseProfileLink(r.Href)
+		}
+		conversations = append(conversations, Conversation{
+			UserID:      userID,
+			Nickname:    r.Nickname,
+			Avatar:      r.Avatar,
+			LastMessage: r.LastMessage,
+			LastTime:    r.LastTime,
+			Unread:      int(xhsutil.ParseCount(r.Unread)),
+		})
+	}
+	return conversations
+}
+
+// findConversation 返回 userID 对应会话的下标，找不到时返回 -1
+func findConversation(conversations []Conversation, userID string) int {
+	for i, c := range conversations {
+		if userID != "" && c.UserID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+func readMessages(page *rod.Page) []DirectMessage {
+	result := page.MustEval(`(selector) => {
+		const items = [];
+		let time = "";
+		document.querySelectorAll(selector).forEach((el) => {
+			if (el.classList.contains('time') || el.classList.contains('message-time')) {
+				time = el.textContent.trim();
+				return;
+			}
+			const content = el.querySelector('.content, .message-content, .text');
+			items.push({
+				fromMe: el.classList.contains('self') || el.classList.contains('mine') || el.classList.contains('right'),
+				content: content ? content.textContent.trim() : el.textContent.trim(),
+				time: time,
+			});
+			time = "";
+		});
+		return JSON.stringify(items);
+	}`, SelectorMessageItems).String()
+
+	var messages []DirectMessage
+	if err := json.Unmarshal([]byte(result), &messages); err != nil {
+		logrus.Warnf("解析私信消息失败: %v", err)
+		return nil
+	}
+	return messages
+}
+
+// lastMessages 取最后 limit 条消息
+func lastMessages(messages []DirectMessage, limit int) []DirectMessage {
+	if len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+	return append([]DirectMessage{}, messages...)
+}
+
+// sentMessageAppeared 判断发送后会话中是否多出了一条自己发出的、内容相同的消息
+func sentMessageAppeared(before, after []DirectMessage, content string) bool {
+	want := normalizeMessageText(content)
+	count := func(messages []DirectMessage) int {
+		n := 0
+		for _, m := range messages {
+			if m.FromMe && normalizeMessageText(m.Content) == want {
+				n++
+			}
+		}
+		return n
+	}
+	return count(after) > count(before)
+}
+
+func normalizeMessageText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,77 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildConversations(t *testing.T) {
+	raw := []rawConversation{
+		{UserID: "u1", Nickname: "小红", LastMessage: "在吗", Unread: "3"},
+		{Href: "/user/profile/u2?xsec_token=t2", Nickname: "小蓝", Unread: "99+"},
+		{Nickname: "系统通知"},
+	}
+
+	conversations := buildConversations(raw)
+	assert.Equal(t, []Conversation{
+		{UserID: "u1", Nickname: "小红", LastMessage: "在吗", Unread: 3},
+		{UserID: "u2", Nickname: "小蓝", Unread: 99},
+		{Nickname: "系统通知"},
+	}, conversations)
+
+	assert.Equal(t, 1, findConversation(conversations, "u2"))
+	assert.Equal(t, -1, findConversation(conversations, "u3"))
+	assert.Equal(t, -1, findConversation(conversations, ""))
+}
+
+func TestLastMessages(t *testing.T) {
+	messages := []DirectMessage{{Content: "a"}, {Content: "b"}, {Content: "c"}}
+	assert.Equal(t, []DirectMessage{{Content: "b"}, {Content: "c"}}, lastMessages(messages, 2))
+	assert.Equal(t, messages, lastMessages(messages, 10))
+}
+
+func TestSentMessageAppeared(t *testing.T) {
+	before := []DirectMessage{
+		{FromMe: true, Content: "早上好"},
+		{FromMe: false, Content: "你好"},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		after   []DirectMessage
+		want    bool
+	}{
+		{
+			name:    "new message from me",
+			content: "你好",
+			after:   append(append([]DirectMessage{}, before...), DirectMessage{FromMe: true, Content: " 你好 "}),
+			want:    true,
+		},
+		{
+			name:    "same content only from the other side",
+			content: "你好",
+			after:   append(append([]DirectMessage{}, before...), DirectMessage{FromMe: false, Content: "你好"}),
+			want:    false,
+		},
+		{
+			name:    "repeat of an earlier message counts once more",
+			content: "早上好",
+			after:   append(append([]DirectMessage{}, before...), DirectMessage{FromMe: true, Content: "早上好"}),
+			want:    true,
+		},
+		{
+			name:    "nothing new",
+			content: "你好",
+			after:   before,
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sentMessageAppeared(before, tt.after, tt.content))
+		})
+	}
+}
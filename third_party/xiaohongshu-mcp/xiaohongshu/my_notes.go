@@ -0,0 +1,409 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+)
+
+const (
+	// urlOfNoteManager 创作中心的笔记管理页
+	urlOfNoteManager = "https://creator.xiaohongshu.com/new/note-manager"
+
+	defaultMyNotesLimit = 20
+	maxMyNotesLimit     = 100
+	// myNotesMaxScrolls 笔记管理页最多滚动次数
+	myNotesMaxScrolls = 20
+	// myNotesStagnantLimit 笔记数连续多少次未增加即认为已到底
+	myNotesStagnantLimit = 3
+
+	// SelectorMyNoteItems 笔记管理页中的笔记
+	SelectorMyNoteItems = "div.note-manager-container div.note, div.notes-list div.note"
+	// SelectorMyNotesEmpty 笔记管理页没有笔记时的空状态提示
+	SelectorMyNotesEmpty = "div.note-manager-container [class*='empty'], div.notes-list [class*='empty'], .empty-container"
+	// SelectorDialogConfirm 创作中心弹窗中的确认按钮
+	SelectorDialogConfirm = ".d-modal button, .el-dialog button, .d-popconfirm button"
+)
+
+// MyNote 当前账号发布的一篇笔记及其数据
+type MyNote struct {
+	NoteID      string `json:"noteId"`
+	XsecToken   string `json:"xsecToken,omitempty"` // 卡片链接中带有时才有
+	Title       string `json:"title"`
+	Cover       string `json:"cover,omitempty"`
+	PublishTime string `json:"publishTime"` // 页面显示的发布时间
+	Views       int64  `json:"views"`
+	Likes       int64  `json:"likes"`
+	Comments    int64  `json:"comments"`
+	Collects    int64  `json:"collects"`
+	Shares      int64  `json:"shares"`
+	Private     bool   `json:"private"`          // 是否仅自己可见
+	Status      string `json:"status,omitempty"` // 审核中、未通过等状态，正常发布时为空
+}
+
+// MyNotesAction 管理当前账号发布的笔记
+type MyNotesAction struct {
+	page *rod.Page
+}
+
+func NewMyNotesAction(page *rod.Page) *MyNotesAction {
+	return &MyNotesAction{page: page}
+}
+
+// ListMyNotes 返回笔记管理页中最近的 limit 篇笔记（最新发布的在前）
+func (a *MyNotesAction) ListMyNotes(ctx context.Context, limit int) ([]MyNote, error) {
+	if limit <= 0 {
+		limit = defaultMyNotesLimit
+	}
+	if limit > maxMyNotesLimit {
+		limit = maxMyNotesLimit
+	}
+
+	page := a.page.Context(ctx).Timeout(2 * time.Minute)
+	if err := openNoteManager(page); err != nil {
+		return nil, err
+	}
+
+	notes := readMyNotes(page)
+	stagnant := 0
+	for i := 0; i < myNotesMaxScrolls && len(notes) < limit; i++ {
+		lastCount := len(notes)
+
+		scrollMyNotes(page)
+		sleepRandom(postScrollRange.min, postScrollRange.max)
+		notes = readMyNotes(page)
+
+		if len(notes) > lastCount {
+			stagnant = 0
+			continue
+		}
+		if stagnant++; stagnant >= myNotesStagnantLimit {
+			break
+		}
+	}
+
+	if len(notes) > limit {
+		notes = notes[:limit]
+	}
+	return notes, nil
+}
+
+// DeleteNote 删除当前账号的一篇笔记；笔记不在笔记管理页中时返回错误
+func (a *MyNotesAction) DeleteNote(ctx context.Context, noteID string) error {
+	// 不使用 Context(ctx)，避免继承外部 context 的超时
+	page := a.page.Timeout(3 * time.Minute)
+
+	item, err := findMyNote(page, noteID)
+	if err != nil {
+		return err
+	}
+	if err := clickNoteOperation(page, item, "删除"); err != nil {
+		return err
+	}
+	if err := confirmDialog(page, "确定", "删除", "确认"); err != nil {
+		return err
+	}
+
+	time.Sleep(2 * time.Second)
+	if _, err := findMyNote(page, noteID); err == nil {
+		return fmt.Errorf("删除笔记 %s 后笔记仍在列表中，可能删除失败", noteID)
+	}
+
+	logrus.Infof("已删除笔记 %s", noteID)
+	return nil
+}
+
+// SetNoteVisibility 把笔记设为仅自己可见（private 为 true）或公开；已处于目标状态时不操作
+// 返回是否实际修改了可见范围
+func (a *MyNotesAction) SetNoteVisibility(ctx context.Context, noteID string, private bool) (bool, error) {
+	page := a.page.Timeout(3 * time.Minute)
+
+	item, err := findMyNote(page, noteID)
+	if err != nil {
+		return false, err
+	}
+	if current := readMyNoteItem(item); current.Private == private {
+		logrus.Infof("笔记 %s 已是目标可见范围 (private=%v)，跳过", noteID, private)
+		return false, nil
+	}
+
+	if err := clickNoteOperation(page, item, "权限设置"); err != nil {
+		return false, err
+	}
+
+	option := "公开可见"
+	if private {
+		option = "仅自己可见"
+	}
+	el, err := page.Timeout(5*time.Second).ElementR(".d-modal label, .d-modal .d-radio, .el-dialog label", regexp.QuoteMeta(option))
+	if err != nil {
+		return false, fmt.Errorf("未找到可见范围选项 %s: %w", option, err)
+	}
+	if err := el.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return false, fmt.Errorf("点击可见范围选项失败: %w", err)
+	}
+	sleepRandom(humanDelayRange.min, humanDelayRange.max)
+
+	if err := confirmDialog(page, "确定", "保存", "确认"); err != nil {
+		return false, err
+	}
+
+	time.Sleep(2 * time.Second)
+	item, err = findMyNote(page, noteID)
+	if err != nil {
+		return false, err
+	}
+	if readMyNoteItem(item).Private != private {
+		return false, fmt.Errorf("修改笔记 %s 的可见范围后状态未变化", noteID)
+	}
+
+	logrus.Infof("已将笔记 %s 设为 private=%v", noteID, private)
+	return true, nil
+}
+
+func openNoteManager(page *rod.Page) error {
+	logrus.Infof("打开笔记管理页: %s", urlOfNoteManager)
+	if err := page.Navigate(urlOfNoteManager); err != nil {
+		return fmt.Errorf("打开笔记管理页失败: %w", err)
+	}
+	if err := page.WaitLoad(); err != nil {
+		logrus.Warnf("等待笔记管理页加载出现问题: %v，继续尝试", err)
+	}
+	if err := page.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待 DOM 稳定出现问题: %v，继续尝试", err)
+	}
+
+	if _, err := page.Timeout(15 * time.Second).Element(SelectorMyNoteItems); err != nil {
+		return fmt.Errorf("笔记管理页没有笔记，或创作中心未登录: %w", err)
+	}
+	return nil
+}
+
+// findMyNote 打开笔记管理页并滚动查找指定笔记
+func findMyNote(page *rod.Page, noteID string) (*rod.Element, error) {
+	if err := openNoteManager(page); err != nil {
+		return nil, err
+	}
+
+	stagnant := 0
+	lastCount := 0
+	for i := 0; i <= myNotesMaxScrolls; i++ {
+		items, err := page.Elements(SelectorMyNoteItems)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if readMyNoteItem(item).NoteID == noteID {
+				item.MustScrollIntoView()
+				return item, nil
+			}
+		}
+
+		if len(items) == lastCount {
+			if stagnant++; stagnant >= myNotesStagnantLimit {
+				break
+			}
+		} else {
+			stagnant = 0
+		}
+		lastCount = len(items)
+
+		scrollMyNotes(page)
+		sleepRandom(postScrollRange.min, postScrollRange.max)
+	}
+	return nil, fmt.Errorf("笔记管理页中未找到笔记 %s，只能管理当前账号发布的笔记", noteID)
+}
+
+// clickNoteOperation 点击笔记卡片上的操作（删除、权限设置等），操作按钮悬停后才显示
+func clickNoteOperation(page *rod.Page, item *rod.Element, name string) error {
+	if err := item.Hover(); err != nil {
+		return fmt.Errorf("悬停笔记卡片失败: %w", err)
+	}
+	sleepRandom(hoverTimeRange.min, hoverTimeRange.max)
+
+	op, err := item.ElementR("span, div, button", "^\\s*"+regexp.QuoteMeta(name)+"\\s*$")
+	if err != nil {
+		// 部分版本的操作收在 "更多" 菜单里
+		more, moreErr := item.ElementR("span, div, button", `^\s*(更多|\.\.\.|···)\s*$`)
+		if moreErr != nil {
+			return fmt.Errorf("未找到笔记操作 %s: %w", name, err)
+		}
+		if err := more.Hover(); err != nil {
+			return fmt.Errorf("展开更多操作失败: %w", err)
+		}
+		sleepRandom(hoverTimeRange.min, hoverTimeRange.max)
+		if op, err = page.Timeout(3*time.Second).ElementR(".d-dropdown-item, .d-popover span, .d-popover div", "^\\s*"+regexp.QuoteMeta(name)+"\\s*$"); err != nil {
+			return fmt.Errorf("未找到笔记操作 %s: %w", name, err)
+		}
+	}
+
+	if err := op.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击笔记操作 %s 失败: %w", name, err)
+	}
+	sleepRandom(reactionTimeRange.min, reactionTimeRange.max)
+	return nil
+}
+
+// confirmDialog 点击弹窗中文字为 labels 之一的按钮
+func confirmDialog(page *rod.Page, labels ...string) error {
+	quoted := make([]string, 0, len(labels))
+	for _, l := range labels {
+		quoted = append(quoted, regexp.QuoteMeta(l))
+	}
+	btn, err := page.Timeout(5*time.Second).ElementR(SelectorDialogConfirm, `^\s*(`+strings.Join(quoted, "|")+`)\s*$`)
+	if err != nil {
+		return fmt.Errorf("未找到确认按钮: %w", err)
+	}
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击确认按钮失败: %w", err)
+	}
+	return nil
+}
+
+func scrollMyNotes(page *rod.Page) {
+	page.MustEval(`(selector) => {
+		const items = document.querySelectorAll(selector);
+		if (items.length > 0) {
+			items[items.length - 1].scrollIntoView({behavior: "smooth", block: "end"});
+		}
+	}`, SelectorMyNoteItems)
+}
+
+// rawNoteStat 笔记卡片上的一项数据，Label 可能为空
+type rawNoteStat struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// rawMyNote 从笔记卡片读取的原始数据
+type rawMyNote struct {
+	Impression string        `json:"impression"`
+	Href       string        `json:"href"`
+	Title      string        `json:"title"`
+	Cover      string        `json:"cover"`
+	Time       string        `json:"time"`
+	Stats      []rawNoteStat `json:"stats"`
+	Text       string        `json:"text"` // 卡片全部文字，用于识别可见范围和审核状态
+}
+
+const readMyNoteJS = `() => {
+	const el = this;
+	const text = (s) => { const n = el.querySelector(s); return n ? n.textContent.trim() : ""; };
+	const link = el.querySelector('a[href*="/explore/"], a[href*="/discovery/item/"]');
+	const cover = el.querySelector('img');
+	const stats = [];
+	el.querySelectorAll('.icon_list .icon, .data .data-item, .stats .stat').forEach((s) => {
+		stats.push({
+			label: s.getAttribute('title') || s.getAttribute('data-type') || (s.querySelector('.label') ? s.querySelector('.label').textContent.trim() : ""),
+			value: (s.querySelector('.value, span') || s).textContent.trim(),
+		});
+	});
+	return JSON.stringify({
+		impression: el.getAttribute('data-impression') || "",
+		href: link ? link.getAttribute('href') || "" : "",
+		title: text('.title, .raw'),
+		cover: cover ? cover.getAttribute('src') || "" : "",
+		time: text('.time'),
+		stats: stats,
+		text: el.textContent,
+	});
+}`
+
+func readMyNotes(page *rod.Page) []MyNote {
+	items, err := page.Elements(SelectorMyNoteItems)
+	if err != nil {
+		return nil
+	}
+	notes := make([]MyNote, 0, len(items))
+	for _, item := range items {
+		if note := readMyNoteItem(item); note.NoteID != "" {
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+func readMyNoteItem(item *rod.Element) MyNote {
+	obj, err := item.Eval(readMyNoteJS)
+	if err != nil {
+		logrus.Warnf("读取笔记卡片失败: %v", err)
+		return MyNote{}
+	}
+	var raw rawMyNote
+	if err := json.Unmarshal([]byte(obj.Value.String()), &raw); err != nil {
+		logrus.Warnf("解析笔记卡片失败: %v", err)
+		return MyNote{}
+	}
+	return buildMyNote(raw)
+}
+
+// impressionNoteIDPattern 笔记卡片 data-impression 中的笔记 ID
+var impressionNoteIDPattern = regexp.MustCompile(`"noteId"\s*:\s*"([0-9a-zA-Z]+)"`)
+
+// noteStatusWords 卡片上表示非正常发布状态的文字
+var noteStatusWords = []string{"审核中", "未通过", "违规", "已删除"}
+
+// buildMyNote 把笔记卡片数据转换为 MyNote
+func buildMyNote(raw rawMyNote) MyNote {
+	note := MyNote{
+		Title:       raw.Title,
+		Cover:       raw.Cover,
+		PublishTime: strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(raw.Time), "发布于")),
+		Private:     strings.Contains(raw.Text, "仅自己可见"),
+	}
+
+	linkID, xsecToken := parseNoteLink(raw.Href)
+	note.XsecToken = xsecToken
+	if m := impressionNoteIDPattern.FindStringSubmatch(raw.Impression); m != nil {
+		note.NoteID = m[1]
+	} else {
+		note.NoteID = linkID
+	}
+
+	for _, word := range noteStatusWords {
+		if strings.Contains(raw.Text, word) {
+			note.Status = word
+			break
+		}
+	}
+
+	// 没有标签时按页面固定顺序：观看、评论、点赞、收藏、分享
+	positional := []*int64{&note.Views, &note.Comments, &note.Likes, &note.Collects, &note.Shares}
+	for i, stat := range raw.Stats {
+		count := xhsutil.ParseCount(stat.Value)
+		if field := noteStatField(&note, stat.Label); field != nil {
+			*field = count
+		} else if stat.Label == "" && i < len(positional) {
+			*positional[i] = count
+		}
+	}
+	return note
+}
+
+// noteStatField 根据数据项名称返回对应字段，无法识别时返回 nil
+func noteStatField(note *MyNote, label string) *int64 {
+	switch {
+	case label == "":
+		return nil
+	case strings.Contains(label, "观看"), strings.Contains(label, "浏览"), strings.Contains(label, "view"):
+		return &note.Views
+	case strings.Contains(label, "评论"), strings.Contains(label, "comment"):
+		return &note.Comments
+	case strings.Contains(label, "点赞"), strings.Contains(label, "赞"), strings.Contains(label, "like"):
+		return &note.Likes
+	case strings.Contains(label, "收藏"), strings.Contains(label, "collect"):
+		return &note.Collects
+	case strings.Contains(label, "分享"), strings.Contains(label, "share"):
+		return &note.Shares
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMyNote(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  rawMyNote
+		want MyNote
+	}{
+		{
+			name: "labelled stats and impression id",
+			raw: rawMyNote{
+				Impression: `{"noteTarget":{"type":"NoteTarget","value":{"noteId":"64f1a2b3c4d5e6f7a8b9c0d1"}}}`,
+				Title:      "今天晒太阳",
+				Time:       "发布于 2025年03月01日 10:21",
+				Stats: []rawNoteStat{
+					{Label: "观看", Value: "1.2万"},
+					{Label: "点赞", Value: "356"},
+					{Label: "收藏", Value: "40"},
+					{Label: "评论", Value: "12"},
+				},
+				Text: "今天晒太阳 发布于 2025年03月01日 10:21",
+			},
+			want: MyNote{
+				NoteID:      "64f1a2b3c4d5e6f7a8b9c0d1",
+				Title:       "今天晒太阳",
+				PublishTime: "2025年03月01日 10:21",
+				Views:       12000,
+				Likes:       356,
+				Collects:    40,
+				Comments:    12,
+			},
+		},
+		{
+			name: "positional stats, link id, private and under review",
+			raw: rawMyNote{
+				Href:  "/explore/n2?xsec_token=t",
+				Title: "散步",
+				Stats: []rawNoteStat{{Value: "100"}, {Value: "2"}, {Value: "9"}, {Value: "1"}, {Value: "0"}},
+				Text:  "散步 仅自己可见 审核中",
+			},
+			want: MyNote{
+				NoteID:    "n2",
+				XsecToken: "t",
+				Title:     "散步",
+				Views:     100,
+				Comments:  2,
+				Likes:     9,
+				Collects:  1,
+				Private:   true,
+				Status:    "审核中",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildMyNote(tt.raw))
+		})
+	}
+}
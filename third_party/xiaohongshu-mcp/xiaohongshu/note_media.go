@@ -0,0 +1,131 @@
+package xiaohongshu
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionRegex 匹配正文中的 @昵称，昵称以空白、@ 或 # 结束
+var mentionRegex = regexp.MustCompile(`@([^\s@#\[\]]+)`)
+
+// BuildNoteMedia 从笔记详情中整理出图片、实况图片和视频流地址
+func BuildNoteMedia(note FeedDetail) *NoteMedia {
+	media := &NoteMedia{
+		Images: make([]NoteImage, 0, len(note.ImageList)),
+	}
+
+	for _, img := range note.ImageList {
+		item := NoteImage{
+			URL:       img.URLDefault,
+			Width:     img.Width,
+			Height:    img.Height,
+			LivePhoto: img.LivePhoto,
+		}
+		if item.URL == "" {
+			item.URL = img.URLPre
+		}
+		if img.LivePhoto && img.Stream != nil {
+			if urls := img.Stream.URLs(); len(urls) > 0 {
+				item.LivePhotoURL = urls[0]
+			}
+		}
+		media.Images = append(media.Images, item)
+	}
+
+	if note.Video != nil {
+		video := &NoteVideo{
+			StreamURLs: note.Video.Media.Stream.URLs(),
+			Duration:   note.Video.Capa.Duration,
+		}
+		if video.Duration == 0 {
+			video.Duration = int(note.Video.Media.Stream.durationMillis() / 1000)
+		}
+		// 视频笔记的第一张图片即为封面
+		if len(media.Images) > 0 {
+			video.CoverURL = media.Images[0].URL
+		}
+		media.Video = video
+	}
+
+	return media
+}
+
+// ImageURLs 返回所有图片地址（视频笔记则为封面）
+func (m *NoteMedia) ImageURLs() []string {
+	if m == nil {
+		return nil
+	}
+
+	urls := make([]string, 0, len(m.Images))
+	for _, img := range m.Images {
+		if img.URL != "" {
+			urls = append(urls, img.URL)
+		}
+	}
+	return urls
+}
+
+// URLs 按 h264、h265、av1 的兼容性顺序返回去重后的主播放地址
+func (s MediaStream) URLs() []string {
+	var urls []string
+	seen := make(map[string]bool)
+
+	for _, group := range [][]StreamInfo{s.H264, s.H265, s.AV1} {
+		for _, info := range group {
+			if info.MasterURL == "" || seen[info.MasterURL] {
+				continue
+			}
+			seen[info.MasterURL] = true
+			urls = append(urls, info.MasterURL)
+		}
+	}
+	return urls
+}
+
+func (s MediaStream) durationMillis() int64 {
+	for _, group := range [][]StreamInfo{s.H264, s.H265, s.AV1} {
+		for _, info := range group {
+			if info.Duration > 0 {
+				return info.Duration
+			}
+		}
+	}
+	return 0
+}
+
+// ParseMentions 解析正文中的 @ 提及，并用 atUserList 补全用户 ID
+func ParseMentions(desc string, atUsers []AtUser) []Mention {
+	byNickname := make(map[string]AtUser, len(atUsers))
+	for _, u := range atUsers {
+		byNickname[u.Nickname] = u
+	}
+
+	var mentions []Mention
+	seen := make(map[string]bool)
+
+	for _, match := range mentionRegex.FindAllStringSubmatch(desc, -1) {
+		nickname := strings.TrimSpace(match[1])
+		if nickname == "" || seen[nickname] {
+			continue
+		}
+		seen[nickname] = true
+
+		m := Mention{Nickname: nickname}
+		if u, ok := byNickname[nickname]; ok {
+			m.UserID = u.UserID
+			m.XsecToken = u.XsecToken
+		}
+		mentions = append(mentions, m)
+	}
+
+	// atUserList 中存在但正文未能解析出的用户也一并返回
+	for _, u := range atUsers {
+		if u.Nickname == "" || seen[u.Nickname] {
+			continue
+		}
+		seen[u.Nickname] = true
+		mentions = append(mentions, Mention{Nickname: u.Nickname, UserID: u.UserID, XsecToken: u.XsecToken})
+	}
+
+	return mentions
+}
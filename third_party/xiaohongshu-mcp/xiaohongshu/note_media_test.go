@@ -0,0 +1,52 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildNoteMedia(t *testing.T) {
+	note := FeedDetail{
+		ImageList: []DetailImageInfo{
+			{URLDefault: "https://img/1.jpg", Width: 1080, Height: 1440},
+			{URLPre: "https://img/2-pre.jpg", LivePhoto: true, Stream: &MediaStream{
+				H264: []StreamInfo{{MasterURL: "https://live/2.mp4"}},
+			}},
+		},
+		Video: &DetailVideo{},
+	}
+	note.Video.Media.Stream = MediaStream{
+		H264: []StreamInfo{{MasterURL: "https://video/a.mp4", Duration: 15300}},
+		H265: []StreamInfo{{MasterURL: "https://video/b.mp4"}, {MasterURL: "https://video/a.mp4"}},
+	}
+
+	media := BuildNoteMedia(note)
+	require.Len(t, media.Images, 2)
+	assert.Equal(t, "https://img/1.jpg", media.Images[0].URL)
+	assert.Equal(t, "https://img/2-pre.jpg", media.Images[1].URL)
+	assert.Equal(t, "https://live/2.mp4", media.Images[1].LivePhotoURL)
+
+	require.NotNil(t, media.Video)
+	assert.Equal(t, []string{"https://video/a.mp4", "https://video/b.mp4"}, media.Video.StreamURLs)
+	assert.Equal(t, 15, media.Video.Duration)
+	assert.Equal(t, "https://img/1.jpg", media.Video.CoverURL)
+
+	assert.Equal(t, []string{"https://img/1.jpg", "https://img/2-pre.jpg"}, media.ImageURLs())
+}
+
+func TestParseMentions(t *testing.T) {
+	desc := "今天和 @小明 一起去爬山 #户外[话题]# 还有@小红\n@小明 下次见"
+	atUsers := []AtUser{
+		{UserID: "u1", Nickname: "小明", XsecToken: "t1"},
+		{UserID: "u3", Nickname: "小刚"},
+	}
+
+	mentions := ParseMentions(desc, atUsers)
+	assert.Equal(t, []Mention{
+		{Nickname: "小明", UserID: "u1", XsecToken: "t1"},
+		{Nickname: "小红"},
+		{Nickname: "小刚", UserID: "u3"},
+	}, mentions)
+}
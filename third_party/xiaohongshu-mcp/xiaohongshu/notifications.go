@@ -0,0 +1,361 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	notificationURL = "https://www.xiaohongshu.com/notification"
+
+	defaultNotificationLimit = 30
+	maxNotificationLimit     = 100
+	// notificationMaxScrolls 每个标签页最多滚动次数
+	notificationMaxScrolls = 20
+	// notificationStagnantLimit 条目数连续多少次未增加即认为已到底
+	notificationStagnantLimit = 3
+
+	// SelectorNotificationItems 通知页当前标签下的通知条目
+	SelectorNotificationItems = ".tabs-content-container .container"
+)
+
+// NotificationTab 通知页的标签
+type NotificationTab string
+
+const (
+	NotificationTabComments NotificationTab = "comments" // 评论和@
+	NotificationTabLikes    NotificationTab = "likes"    // 赞和收藏
+	NotificationTabFollows  NotificationTab = "follows"  // 新增关注
+)
+
+// AllNotificationTabs 通知页的全部标签，按页面顺序
+var AllNotificationTabs = []NotificationTab{NotificationTabComments, NotificationTabLikes, NotificationTabFollows}
+
+// ParseNotificationTabs 解析标签名列表，忽略空项；为空时返回 nil 表示全部标签
+func ParseNotificationTabs(names []string) ([]NotificationTab, error) {
+	var tabs []NotificationTab
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		tab := NotificationTab(name)
+		if tab.title() == "" {
+			return nil, fmt.Errorf("未知的通知标签: %s，可选 comments、likes、follows", name)
+		}
+		tabs = append(tabs, tab)
+	}
+	return tabs, nil
+}
+
+// title 标签在页面上的名称
+func (t NotificationTab) title() string {
+	switch t {
+	case NotificationTabComments:
+		return "评论和@"
+	case NotificationTabLikes:
+		return "赞和收藏"
+	case NotificationTabFollows:
+		return "新增关注"
+	default:
+		return ""
+	}
+}
+
+// NotificationType 通知类型
+type NotificationType string
+
+const (
+	NotificationComment NotificationType = "comment" // 评论或回复了我
+	NotificationMention NotificationType = "mention" // 在评论或笔记中@了我
+	NotificationLike    NotificationType = "like"    // 赞了我的笔记或评论
+	NotificationCollect NotificationType = "collect" // 收藏了我的笔记
+	NotificationFollow  NotificationType = "follow"  // 关注了我
+)
+
+// Notification 一条通知
+type Notification struct {
+	Tab           NotificationTab  `json:"tab"`
+	Type          NotificationType `json:"type"`
+	UserID        string           `json:"userId"`
+	Nickname      string           `json:"nickname"`
+	UserXsecToken string           `json:"userXsecToken,omitempty"`
+	Action        string           `json:"action"`            // 页面上的动作描述，如 "回复了你的评论"
+	Content       string           `json:"content,omitempty"` // 评论/回复内容
+	NoteID        string           `json:"noteId,omitempty"`
+	NoteXsecToken string           `json:"noteXsecToken,omitempty"`
+	TimeText      string           `json:"timeText"`       // 页面显示的时间，如 "3分钟前"
+	Time          int64            `json:"time,omitempty"` // 由 TimeText 推算的 Unix 秒，无法推算时为 0
+}
+
+// NotificationAction 读取通知页
+type NotificationAction struct {
+	page *rod.Page
+}
+
+func NewNotificationAction(page *rod.Page) *NotificationAction {
+	return &NotificationAction{page: page}
+}
+
+// Notifications 依次读取各标签下的通知，只返回晚于 since 的通知（since 为零值时不过滤）
+// 每个标签最多返回 limit 条；通知按页面顺序（最新的在前），读到早于 since 的通知即停止滚动
+func (n *NotificationAction) Notifications(ctx context.Context, tabs []NotificationTab, since time.Time, limit int) ([]Notification, error) {
+	if len(tabs) == 0 {
+		tabs = AllNotificationTabs
+	}
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+	if limit > maxNotificationLimit {
+		limit = maxNotificationLimit
+	}
+
+	page := n.page.Context(ctx).Timeout(3 * time.Minute)
+	logrus.Infof("打开通知页: %s", notificationURL)
+	page.MustNavigate(notificationURL)
+	page.MustWaitDOMStable()
+
+	var all []Notification
+	for _, tab := range tabs {
+		if tab.title() == "" {
+			return nil, fmt.Errorf("未知的通知标签: %s", tab)
+		}
+		if err := openNotificationTab(page, tab); err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		items := readNotifications(page, tab, now)
+		stagnant := 0
+		for i := 0; i < notificationMaxScrolls && !notificationsEnough(items, since, limit); i++ {
+			lastCount := len(items)
+
+			scrollNotifications(page)
+			sleepRandom(postScrollRange.min, postScrollRange.max)
+			items = readNotifications(page, tab, now)
+
+			if len(items) > lastCount {
+				stagnant = 0
+				continue
+			}
+			if stagnant++; stagnant >= notificationStagnantLimit {
+				break
+			}
+		}
+
+		items = filterNotificationsSince(items, since)
+		if len(items) > limit {
+			items = items[:limit]
+		}
+		logrus.Infof("通知标签 %s 读取到 %d 条", tab.title(), len(items))
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+func openNotificationTab(page *rod.Page, tab NotificationTab) error {
+	el, err := page.Timeout(10*time.Second).ElementR(".reds-tabs-list .reds-tab-item, .tabs .tab-item", "^\\s*"+regexp.QuoteMeta(tab.title()))
+	if err != nil {
+		return fmt.Errorf("未找到通知标签 %s，请确认已登录: %w", tab.title(), err)
+	}
+	if err := el.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return fmt.Errorf("点击通知标签 %s 失败: %w", tab.title(), err)
+	}
+	sleepRandom(readTimeRange.min, readTimeRange.max)
+
+	if _, err := page.Timeout(5 * time.Second).Element(SelectorNotificationItems); err != nil {
+		logrus.Infof("通知标签 %s 暂无通知", tab.title())
+	}
+	return nil
+}
+
+func scrollNotifications(page *rod.Page) {
+	page.MustEval(`(selector) => {
+		const items = document.querySelectorAll(selector);
+		if (items.length > 0) {
+			items[items.length - 1].scrollIntoView({behavior: "smooth", block: "end"});
+		} else {
+			window.scrollBy(0, window.innerHeight);
+		}
+	}`, SelectorNotificationItems)
+}
+
+// rawNotification 从页面读取的原始通知条目
+type rawNotification struct {
+	UserHref string `json:"userHref"`
+	Nickname string `json:"nickname"`
+	Hint     string `json:"hint"`
+	Time     string `json:"time"`
+	Content  string `json:"content"`
+	NoteHref string `json:"noteHref"`
+}
+
+func readNotifications(page *rod.Page, tab NotificationTab, now time.Time) []Notification {
+	result := page.MustEval(`(selector) => {
+		const items = [];
+		document.querySelectorAll(selector).forEach((el) => {
+			const text = (s) => { const n = el.querySelector(s); return n ? n.textContent.trim() : ""; };
+			const user = el.querySelector('.user-info a[href*="/user/profile/"], a.user-avatar, a[href*="/user/profile/"]');
+			const note = el.querySelector('a[href*="/explore/"], a[href*="/discovery/item/"]');
+			items.push({
+				userHref: user ? user.getAttribute('href') || "" : "",
+				nickname: text('.user-info a, .user-info .name, .name'),
+				hint: text('.interaction-hint'),
+				time: text('.interaction-hint .time, .interaction-time'),
+				content: text('.interaction-content'),
+				noteHref: note ? note.getAttribute('href') || "" : "",
+			});
+		});
+		return JSON.stringify(items);
+	}`, SelectorNotificationItems).String()
+
+	var raw []rawNotification
+	if err := json.Unmarshal([]byte(result), &raw); err != nil {
+		logrus.Warnf("解析通知失败: %v", err)
+		return nil
+	}
+	return buildNotifications(raw, tab, now)
+}
+
+// buildNotifications 把页面条目转换为通知，推算时间并识别类型
+func buildNotifications(raw []rawNotification, tab NotificationTab, now time.Time) []Notification {
+	list := make([]Notification, 0, len(raw))
+	for _, r := range raw {
+		action, timeText := splitNotificationHint(r.Hint, r.Time)
+		userID, userToken := parseProfileLink(r.UserHref)
+		noteID, noteToken := parseNoteLink(r.NoteHref)
+
+		n := Notification{
+			Tab:           tab,
+			Type:          classifyNotification(tab, action),
+			UserID:        userID,
+			Nickname:      r.Nickname,
+			UserXsecToken: userToken,
+			Action:        action,
+			Content:       r.Content,
+			NoteID:        noteID,
+			NoteXsecToken: noteToken,
+			TimeText:      timeText,
+		}
+		if t, ok := parseNotificationTime(timeText, now); ok {
+			n.Time = t.Unix()
+		}
+		list = append(list, n)
+	}
+	return list
+}
+
+// notificationTimePattern 出现在动作描述末尾的时间文字
+var notificationTimePattern = regexp.MustCompile(`\s*(刚刚|\d+\s*(秒|分钟|小时|天)前|(今天|昨天)\s*\d{1,2}:\d{2}|(\d{4}-)?\d{1,2}-\d{1,2})$`)
+
+// splitNotificationHint 从 "回复了你的评论 3分钟前" 中拆出动作描述和时间；页面单独给出时间时优先使用
+func splitNotificationHint(hint, timeText string) (action, when string) {
+	hint = strings.Join(strings.Fields(hint), " ")
+	timeText = strings.TrimSpace(timeText)
+	if timeText != "" {
+		return strings.TrimSpace(strings.TrimSuffix(hint, timeText)), timeText
+	}
+	if loc := notificationTimePattern.FindStringIndex(hint); loc != nil {
+		return strings.TrimSpace(hint[:loc[0]]), strings.TrimSpace(hint[loc[0]:])
+	}
+	return hint, ""
+}
+
+// classifyNotification 根据所在标签和动作描述判断通知类型
+func classifyNotification(tab NotificationTab, action string) NotificationType {
+	switch tab {
+	case NotificationTabLikes:
+		if strings.Contains(action, "收藏") {
+			return NotificationCollect
+		}
+		return NotificationLike
+	case NotificationTabFollows:
+		return NotificationFollow
+	default:
+		if strings.Contains(action, "@") || strings.Contains(action, "提到") {
+			return NotificationMention
+		}
+		return NotificationComment
+	}
+}
+
+var (
+	relativeTimePattern = regexp.MustCompile(`^(\d+)\s*(秒|分钟|小时|天)前$`)
+	dayClockPattern     = regexp.MustCompile(`^(今天|昨天)\s*(\d{1,2}):(\d{2})$`)
+)
+
+// parseNotificationTime 把页面显示的时间文字换算为具体时间
+// 支持 "刚刚"、"N秒/分钟/小时/天前"、"今天/昨天 HH:MM"、"MM-DD"、"YYYY-MM-DD"
+func parseNotificationTime(text string, now time.Time) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return time.Time{}, false
+	}
+	if text == "刚刚" {
+		return now, true
+	}
+
+	if m := relativeTimePattern.FindStringSubmatch(text); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		unit := map[string]time.Duration{"秒": time.Second, "分钟": time.Minute, "小时": time.Hour, "天": 24 * time.Hour}[m[2]]
+		return now.Add(-time.Duration(n) * unit), true
+	}
+
+	if m := dayClockPattern.FindStringSubmatch(text); m != nil {
+		hour, _ := strconv.Atoi(m[2])
+		minute, _ := strconv.Atoi(m[3])
+		day := now
+		if m[1] == "昨天" {
+			day = now.AddDate(0, 0, -1)
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location()), true
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", text, now.Location()); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation("01-02", text, now.Location()); err == nil {
+		t = t.AddDate(now.Year(), 0, 0)
+		// 不带年份的日期不会晚于今天，晚于今天说明是去年
+		if t.After(now) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// notificationsEnough 已加载的通知是否足够：达到 limit，或已经读到早于 since 的通知
+func notificationsEnough(list []Notification, since time.Time, limit int) bool {
+	if len(filterNotificationsSince(list, since)) >= limit {
+		return true
+	}
+	if since.IsZero() || len(list) == 0 {
+		return false
+	}
+	last := list[len(list)-1]
+	return last.Time != 0 && last.Time < since.Unix()
+}
+
+// filterNotificationsSince 保留晚于 since 的通知；时间无法推算的通知保留，交给调用方判断
+func filterNotificationsSince(list []Notification, since time.Time) []Notification {
+	if since.IsZero() {
+		return list
+	}
+	kept := make([]Notification, 0, len(list))
+	for _, n := range list {
+		if n.Time == 0 || n.Time >= since.Unix() {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,123 @@
+package xiaohongshu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNotificationTime(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		text string
+		want time.Time
+		ok   bool
+	}{
+		{text: "刚刚", want: now, ok: true},
+		{text: "30秒前", want: now.Add(-30 * time.Second), ok: true},
+		{text: "5分钟前", want: now.Add(-5 * time.Minute), ok: true},
+		{text: "2小时前", want: now.Add(-2 * time.Hour), ok: true},
+		{text: "3天前", want: now.Add(-72 * time.Hour), ok: true},
+		{text: "今天 09:05", want: time.Date(2025, 3, 10, 9, 5, 0, 0, time.Local), ok: true},
+		{text: "昨天 23:10", want: time.Date(2025, 3, 9, 23, 10, 0, 0, time.Local), ok: true},
+		{text: "03-01", want: time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local), ok: true},
+		{text: "12-25", want: time.Date(2024, 12, 25, 0, 0, 0, 0, time.Local), ok: true},
+		{text: "2023-06-18", want: time.Date(2023, 6, 18, 0, 0, 0, 0, time.Local), ok: true},
+		{text: "很久以前", ok: false},
+		{text: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := parseNotificationTime(tt.text, now)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.True(t, tt.want.Equal(got), "want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSplitNotificationHint(t *testing.T) {
+	tests := []struct {
+		hint, timeText string
+		action, when   string
+	}{
+		{hint: "回复了你的评论 3分钟前", action: "回复了你的评论", when: "3分钟前"},
+		{hint: "赞了你的笔记  昨天 12:30", action: "赞了你的笔记", when: "昨天 12:30"},
+		{hint: "开始关注你了 03-01", action: "开始关注你了", when: "03-01"},
+		{hint: "在评论中@了你 刚刚", timeText: "刚刚", action: "在评论中@了你", when: "刚刚"},
+		{hint: "收藏了你的笔记", action: "收藏了你的笔记", when: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hint, func(t *testing.T) {
+			action, when := splitNotificationHint(tt.hint, tt.timeText)
+			assert.Equal(t, tt.action, action)
+			assert.Equal(t, tt.when, when)
+		})
+	}
+}
+
+func TestClassifyNotification(t *testing.T) {
+	assert.Equal(t, NotificationComment, classifyNotification(NotificationTabComments, "回复了你的评论"))
+	assert.Equal(t, NotificationMention, classifyNotification(NotificationTabComments, "在评论中@了你"))
+	assert.Equal(t, NotificationLike, classifyNotification(NotificationTabLikes, "赞了你的笔记"))
+	assert.Equal(t, NotificationCollect, classifyNotification(NotificationTabLikes, "收藏了你的笔记"))
+	assert.Equal(t, NotificationFollow, classifyNotification(NotificationTabFollows, "开始关注你了"))
+}
+
+func TestBuildNotifications(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.Local)
+	raw := []rawNotification{{
+		UserHref: "/user/profile/u1?xsec_token=ut",
+		Nickname: "小红",
+		Hint:     "评论了你的笔记 5分钟前",
+		Content:  "好可爱",
+		NoteHref: "/explore/n1?xsec_token=nt",
+	}}
+
+	list := buildNotifications(raw, NotificationTabComments, now)
+	require.Len(t, list, 1)
+	assert.Equal(t, Notification{
+		Tab:           NotificationTabComments,
+		Type:          NotificationComment,
+		UserID:        "u1",
+		Nickname:      "小红",
+		UserXsecToken: "ut",
+		Action:        "评论了你的笔记",
+		Content:       "好可爱",
+		NoteID:        "n1",
+		NoteXsecToken: "nt",
+		TimeText:      "5分钟前",
+		Time:          now.Add(-5 * time.Minute).Unix(),
+	}, list[0])
+}
+
+func TestFilterNotificationsSince(t *testing.T) {
+	since := time.Unix(1000, 0)
+	list := []Notification{{Time: 2000}, {Time: 1000}, {Time: 0}, {Time: 500}}
+
+	assert.Equal(t, []Notification{{Time: 2000}, {Time: 1000}, {Time: 0}}, filterNotificationsSince(list, since))
+	assert.Equal(t, list, filterNotificationsSince(list, time.Time{}))
+
+	assert.True(t, notificationsEnough(list, since, 10), "oldest loaded notification is before since")
+	assert.False(t, notificationsEnough(list[:2], since, 10))
+	assert.True(t, notificationsEnough(list[:2], time.Time{}, 2))
+}
+
+func TestParseNotificationTabs(t *testing.T) {
+	tabs, err := ParseNotificationTabs([]string{" likes", "", "follows"})
+	require.NoError(t, err)
+	assert.Equal(t, []NotificationTab{NotificationTabLikes, NotificationTabFollows}, tabs)
+
+	tabs, err = ParseNotificationTabs(nil)
+	require.NoError(t, err)
+	assert.Nil(t, tabs)
+
+	_, err = ParseNotificationTabs([]string{"messages"})
+	assert.Error(t, err)
+}
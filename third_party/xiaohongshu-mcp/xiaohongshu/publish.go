@@ -20,8 +20,10 @@ type PublishImageContent struct {
 	Title        string
 	Content      string
 	Tags         []string
+	Mentions     []string // 要 @ 的用户 ID 或昵称
 	ImagePaths   []string
 	ScheduleTime *time.Time // 定时发布时间，nil 表示立即发布
+	Draft        bool       // 填写完成后存入草稿箱，不发布
 }
 
 type PublishAction struct {
@@ -65,15 +67,17 @@ func NewPublishImageAction(page *rod.Page) (*PublishAction, error) {
 	}, nil
 }
 
-func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent) error {
+// Publish 发布图文，返回新笔记的信息；存入草稿箱或未能获取笔记 ID 时返回 nil
+func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent) (*PublishedNote, error) {
 	if len(content.ImagePaths) == 0 {
-		return errors.New("图片不能为空")
+		return nil, errors.New("图片不能为空")
 	}
 
 	page := p.page.Context(ctx)
+	before := notesBeforePublish(page, content.Draft)
 
 	if err := uploadImages(page, content.ImagePaths); err != nil {
-		return errors.Wrap(err, "小红书上传图片失败")
+		return nil, errors.Wrap(err, "小红书上传图片失败")
 	}
 
 	tags := content.Tags
@@ -82,13 +86,16 @@ func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent
 		tags = tags[:10]
 	}
 
-	logrus.Infof("发布内容: title=%s, images=%v, tags=%v, schedule=%v", content.Title, len(content.ImagePaths), tags, content.ScheduleTime)
+	logrus.Infof("发布内容: title=%s, images=%v, tags=%v, schedule=%v, draft=%v", content.Title, len(content.ImagePaths), tags, content.ScheduleTime, content.Draft)
 
-	if err := submitPublish(page, content.Title, content.Content, tags, content.ScheduleTime); err != nil {
-		return errors.Wrap(err, "小红书发布失败")
+	if err := submitPublish(page, content.Title, content.Content, tags, content.Mentions, content.ScheduleTime, content.Draft); err != nil {
+		return nil, errors.Wrap(err, "小红书发布失败")
+	}
+	if content.Draft {
+		return nil, nil
 	}
 
-	return nil
+	return lookupPublishedNote(page, content.Title, before), nil
 }
 
 func removePopCover(page *rod.Page) {
@@ -268,7 +275,7 @@ func waitForUploadComplete(page *rod.Page, expectedCount int) error {
 	return errors.Errorf("第%d张图片上传超时(60s)，请检查网络连接和图片大小", expectedCount)
 }
 
-func submitPublish(page *rod.Page, title, content string, tags []string, scheduleTime *time.Time) error {
+func submitPublish(page *rod.Page, title, content string, tags, mentions []string, scheduleTime *time.Time, draft bool) error {
 	titleElem, err := page.Element("div.d-input input")
 	if err != nil {
 		return errors.Wrap(err, "查找标题输入框失败")
@@ -293,6 +300,9 @@ func submitPublish(page *rod.Page, title, content string, tags []string, schedul
 	if err := contentElem.Input(content); err != nil {
 		return errors.Wrap(err, "输入正文失败")
 	}
+	if _, err := insertMentions(contentElem, publishMentionPicker, mentions); err != nil {
+		return err
+	}
 	if err := inputTags(contentElem, tags); err != nil {
 		return err
 	}
@@ -313,6 +323,10 @@ func submitPublish(page *rod.Page, title, content string, tags []string, schedul
 		slog.Info("定时发布设置完成", "schedule_time", scheduleTime.Format("2006-01-02 15:04"))
 	}
 
+	if draft {
+		return saveDraft(page, DraftKindImage, title)
+	}
+
 	submitButton, err := page.Element(".publish-page-publish-btn button.bg-red")
 	if err != nil {
 		return errors.Wrap(err, "查找发布按钮失败")
@@ -321,7 +335,30 @@ func submitPublish(page *rod.Page, title, content string, tags []string, schedul
 		return errors.Wrap(err, "点击发布按钮失败")
 	}
 
+	return waitPublishSuccess(page)
+}
+
+// saveDraft 点击 "暂存离开"，把已填写的内容存入草稿箱，
+// 再打开草稿箱确认最新一篇草稿就是这篇
+func saveDraft(page *rod.Page, kind DraftKind, title string) error {
+	btn, err := page.Timeout(5*time.Second).ElementR(".publish-page-publish-btn button", `暂存离开|存草稿|保存草稿`)
+	if err != nil {
+		return errors.Wrap(err, "查找暂存按钮失败")
+	}
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击暂存按钮失败")
+	}
+
 	time.Sleep(3 * time.Second)
+
+	if err := openDraftBox(page, kind); err != nil {
+		return errors.Wrap(err, "暂存后打开草稿箱核对失败")
+	}
+	drafts := readDrafts(page, kind)
+	if len(drafts) == 0 || strings.TrimSpace(drafts[0].Title) != strings.TrimSpace(title) {
+		return errors.Errorf("暂存后草稿箱中最新的草稿不是 %q，可能未保存成功", title)
+	}
+	slog.Info("已存入草稿箱", "title", title)
 	return nil
 }
 
@@ -0,0 +1,185 @@
+package xiaohongshu
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// publishSuccessTimeout 点击发布后等待成功页的最长时间
+	publishSuccessTimeout = 60 * time.Second
+	// publishedNoteAttempts 在笔记管理页查找新笔记的次数，新笔记可能稍后才出现
+	publishedNoteAttempts = 3
+
+	// SelectorPublishToast 发布页的提示信息
+	SelectorPublishToast = ".d-toast, .d-message, .el-message"
+)
+
+// PublishedNote 发布成功后新笔记的信息
+type PublishedNote struct {
+	NoteID    string `json:"noteId"`
+	XsecToken string `json:"xsecToken,omitempty"`
+	URL       string `json:"url"`
+}
+
+// waitPublishSuccess 等待发布成功页或 "发布成功" 提示，超时视为发布失败
+func waitPublishSuccess(page *rod.Page) error {
+	deadline := time.Now().Add(publishSuccessTimeout)
+	var lastToast string
+	for time.Now().Before(deadline) {
+		if info, err := page.Info(); err == nil && isPublishSuccessURL(info.URL) {
+			logrus.Infof("已进入发布成功页: %s", info.URL)
+			return nil
+		}
+		if toast := publishToastText(page); toast != "" {
+			if strings.Contains(toast, "发布成功") {
+				logrus.Infof("发布成功提示: %s", toast)
+				return nil
+			}
+			lastToast = toast
+		}
+		time.Sleep(time.Second)
+	}
+
+	if lastToast != "" {
+		return fmt.Errorf("点击发布后未进入发布成功页，页面提示: %s", lastToast)
+	}
+	return errors.New("点击发布后未进入发布成功页，笔记可能没有发出")
+}
+
+// isPublishSuccessURL 发布成功后创作中心会跳转到成功页
+func isPublishSuccessURL(u string) bool {
+	return strings.Contains(u, "/publish/success") || strings.Contains(u, "published=true")
+}
+
+func publishToastText(page *rod.Page) string {
+	items, err := page.Elements(SelectorPublishToast)
+	if err != nil {
+		return ""
+	}
+	texts := make([]string, 0, len(items))
+	for _, item := range items {
+		if text, err := item.Text(); err == nil && strings.TrimSpace(text) != "" {
+			texts = append(texts, strings.TrimSpace(text))
+		}
+	}
+	return strings.Join(texts, "; ")
+}
+
+// snapshotMyNoteIDs 在新标签页打开笔记管理页，记录发布前已有的笔记 ID。
+// 发布后只认不在其中的笔记，避免把同标题的旧笔记当成新笔记
+func snapshotMyNoteIDs(page *rod.Page) (map[string]bool, error) {
+	tab, err := page.Browser().Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return nil, fmt.Errorf("打开新标签页失败: %w", err)
+	}
+	defer tab.Close()
+	tab = tab.Timeout(time.Minute)
+
+	if err := tab.Navigate(urlOfNoteManager); err != nil {
+		return nil, fmt.Errorf("打开笔记管理页失败: %w", err)
+	}
+	if err := tab.WaitLoad(); err != nil {
+		logrus.Warnf("等待笔记管理页加载出现问题: %v，继续尝试", err)
+	}
+	if err := tab.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待 DOM 稳定出现问题: %v，继续尝试", err)
+	}
+
+	ids := make(map[string]bool)
+	if _, err := tab.Timeout(15 * time.Second).Element(SelectorMyNoteItems); err != nil {
+		// 只有页面明确显示没有笔记时才当作空列表；否则可能是页面没加载出来，
+		// 把旧笔记当成不存在会让同标题的旧笔记被认作新笔记
+		if noteManagerEmpty(tab) {
+			return ids, nil
+		}
+		return nil, fmt.Errorf("笔记管理页未显示笔记列表: %w", err)
+	}
+	for _, note := range readMyNotes(tab) {
+		ids[note.NoteID] = true
+	}
+	return ids, nil
+}
+
+// noteManagerEmpty 笔记管理页是否显示了"暂无笔记"之类的空状态
+func noteManagerEmpty(page *rod.Page) bool {
+	_, err := page.Timeout(2*time.Second).ElementR(SelectorMyNotesEmpty, `暂无|还没有`)
+	return err == nil
+}
+
+// notesBeforePublish 发布前记录已有笔记；存草稿时不需要，失败时返回 nil
+func notesBeforePublish(page *rod.Page, draft bool) map[string]bool {
+	if draft {
+		return nil
+	}
+	before, err := snapshotMyNoteIDs(page)
+	if err != nil {
+		logrus.Warnf("记录发布前的笔记失败: %v", err)
+		return nil
+	}
+	return before
+}
+
+// findPublishedNote 在笔记管理页按标题查找发布前不存在的笔记
+func findPublishedNote(page *rod.Page, title string, before map[string]bool) (*PublishedNote, error) {
+	var lastErr error
+	for i := 0; i < publishedNoteAttempts; i++ {
+		if i > 0 {
+			time.Sleep(5 * time.Second)
+		}
+		if err := openNoteManager(page); err != nil {
+			lastErr = err
+			continue
+		}
+		if note, ok := matchPublishedNote(readMyNotes(page), title, before); ok {
+			return &PublishedNote{
+				NoteID:    note.NoteID,
+				XsecToken: note.XsecToken,
+				URL:       NoteURL(note.NoteID, note.XsecToken),
+			}, nil
+		}
+		lastErr = fmt.Errorf("笔记管理页中没有标题为 %q 的新笔记", title)
+	}
+	return nil, lastErr
+}
+
+// matchPublishedNote 返回发布前不存在、标题一致的最新一篇笔记，笔记管理页最新发布的在前
+func matchPublishedNote(notes []MyNote, title string, before map[string]bool) (MyNote, bool) {
+	title = strings.TrimSpace(title)
+	for _, note := range notes {
+		if !before[note.NoteID] && strings.TrimSpace(note.Title) == title {
+			return note, true
+		}
+	}
+	return MyNote{}, false
+}
+
+// NoteURL 笔记的网页链接，没有 xsec_token 时只能由作者本人打开
+func NoteURL(noteID, xsecToken string) string {
+	if xsecToken == "" {
+		return "https://www.xiaohongshu.com/explore/" + noteID
+	}
+	return makeFeedDetailURL(noteID, xsecToken)
+}
+
+// lookupPublishedNote 发布成功后查找新笔记；找不到时只记录警告，不影响发布结果。
+// before 为 nil 表示发布前没能记录已有笔记，此时无法区分新旧笔记，不再查找
+func lookupPublishedNote(page *rod.Page, title string, before map[string]bool) *PublishedNote {
+	if before == nil {
+		logrus.Warn("笔记已发布，但发布前未能记录已有笔记，无法确认新笔记 ID")
+		return nil
+	}
+	note, err := findPublishedNote(page, title, before)
+	if err != nil {
+		logrus.Warnf("笔记已发布，但未能获取笔记 ID: %v", err)
+		return nil
+	}
+	logrus.Infof("新笔记: %s", note.URL)
+	return note
+}
@@ -0,0 +1,41 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublishSuccessURL(t *testing.T) {
+	assert.True(t, isPublishSuccessURL("https://creator.xiaohongshu.com/publish/success?source=official"))
+	assert.True(t, isPublishSuccessURL("https://creator.xiaohongshu.com/new/note-manager?published=true"))
+	assert.False(t, isPublishSuccessURL("https://creator.xiaohongshu.com/publish/publish?source=official"))
+}
+
+func TestMatchPublishedNote(t *testing.T) {
+	notes := []MyNote{
+		{NoteID: "n3", Title: "散步"},
+		{NoteID: "n2", Title: "晒太阳"},
+		{NoteID: "n1", Title: "晒太阳"},
+	}
+
+	note, ok := matchPublishedNote(notes, " 晒太阳 ", map[string]bool{})
+	assert.True(t, ok)
+	assert.Equal(t, "n2", note.NoteID, "newest note with the title wins")
+
+	_, ok = matchPublishedNote(notes, "看海", map[string]bool{})
+	assert.False(t, ok)
+
+	// 新笔记还没出现在笔记管理页时，不能把同标题的旧笔记当成新笔记
+	_, ok = matchPublishedNote(notes, "晒太阳", map[string]bool{"n1": true, "n2": true, "n3": true})
+	assert.False(t, ok)
+
+	note, ok = matchPublishedNote(append([]MyNote{{NoteID: "n4", Title: "晒太阳"}}, notes...), "晒太阳", map[string]bool{"n1": true, "n2": true, "n3": true})
+	assert.True(t, ok)
+	assert.Equal(t, "n4", note.NoteID)
+}
+
+func TestNoteURL(t *testing.T) {
+	assert.Equal(t, "https://www.xiaohongshu.com/explore/n1?xsec_token=tok&xsec_source=pc_feed", NoteURL("n1", "tok"))
+	assert.Equal(t, "https://www.xiaohongshu.com/explore/n1", NoteURL("n1", ""))
+}
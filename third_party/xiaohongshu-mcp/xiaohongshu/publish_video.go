@@ -18,8 +18,10 @@ type PublishVideoContent struct {
 	Title        string
 	Content      string
 	Tags         []string
+	Mentions     []string // 要 @ 的用户 ID 或昵称
 	VideoPath    string
 	ScheduleTime *time.Time // 定时发布时间，nil 表示立即发布
+	Draft        bool       // 填写完成后存入草稿箱，不发布
 }
 
 // NewPublishVideoAction 进入发布页并切换到"上传视频"
@@ -51,21 +53,25 @@ func NewPublishVideoAction(page *rod.Page) (*PublishAction, error) {
 }
 
 // PublishVideo 上传视频并提交
-func (p *PublishAction) PublishVideo(ctx context.Context, content PublishVideoContent) error {
+func (p *PublishAction) PublishVideo(ctx context.Context, content PublishVideoContent) (*PublishedNote, error) {
 	if content.VideoPath == "" {
-		return errors.New("视频不能为空")
+		return nil, errors.New("视频不能为空")
 	}
 
 	page := p.page.Context(ctx)
+	before := notesBeforePublish(page, content.Draft)
 
 	if err := uploadVideo(page, content.VideoPath); err != nil {
-		return errors.Wrap(err, "小红书上传视频失败")
+		return nil, errors.Wrap(err, "小红书上传视频失败")
 	}
 
-	if err := submitPublishVideo(page, content.Title, content.Content, content.Tags, content.ScheduleTime); err != nil {
-		return errors.Wrap(err, "小红书发布失败")
+	if err := submitPublishVideo(page, content.Title, content.Content, content.Tags, content.Mentions, content.ScheduleTime, content.Draft); err != nil {
+		return nil, errors.Wrap(err, "小红书发布失败")
 	}
-	return nil
+	if content.Draft {
+		return nil, nil
+	}
+	return lookupPublishedNote(page, content.Title, before), nil
 }
 
 // uploadVideo 上传单个本地视频
@@ -130,7 +136,8 @@ func waitForPublishButtonClickable(page *rod.Page) (*rod.Element, error) {
 }
 
 // submitPublishVideo 填写标题、正文、标签并点击发布（等待按钮可点击后再提交）
-func submitPublishVideo(page *rod.Page, title, content string, tags []string, scheduleTime *time.Time) error {
+// draft 为 true 时等视频处理完成后存入草稿箱
+func submitPublishVideo(page *rod.Page, title, content string, tags, mentions []string, scheduleTime *time.Time, draft bool) error {
 	// 标题
 	titleElem, err := page.Element("div.d-input input")
 	if err != nil {
@@ -141,7 +148,7 @@ func submitPublishVideo(page *rod.Page, title, content string, tags []string, sc
 	}
 	time.Sleep(1 * time.Second)
 
-	// 正文 + 标签
+	// 正文 + 提及 + 标签
 	contentElem, ok := getContentElement(page)
 	if !ok {
 		return errors.New("没有找到内容输入框")
@@ -149,6 +156,9 @@ func submitPublishVideo(page *rod.Page, title, content string, tags []string, sc
 	if err := contentElem.Input(content); err != nil {
 		return errors.Wrap(err, "输入正文失败")
 	}
+	if _, err := insertMentions(contentElem, publishMentionPicker, mentions); err != nil {
+		return err
+	}
 	if err := inputTags(contentElem, tags); err != nil {
 		return err
 	}
@@ -169,11 +179,14 @@ func submitPublishVideo(page *rod.Page, title, content string, tags []string, sc
 		return err
 	}
 
+	if draft {
+		return saveDraft(page, DraftKindVideo, title)
+	}
+
 	// 点击发布
 	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
 		return errors.Wrap(err, "点击发布按钮失败")
 	}
 
-	time.Sleep(3 * time.Second)
-	return nil
+	return waitPublishSuccess(page)
 }
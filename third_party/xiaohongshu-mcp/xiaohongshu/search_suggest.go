@@ -0,0 +1,211 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+)
+
+// TrendingKeyword 热搜榜条目
+type TrendingKeyword struct {
+	Rank    int    `json:"rank"`
+	Keyword string `json:"keyword"`
+	Heat    string `json:"heat,omitempty"`    // 热度原始文本，如 "92.3万"
+	HeatNum int64  `json:"heatNum,omitempty"` // 热度数值
+	Label   string `json:"label,omitempty"`   // 标签，如 "热"、"新"
+}
+
+type trendingItemRaw struct {
+	Text  string `json:"text"`
+	Heat  string `json:"heat"`
+	Label string `json:"label"`
+}
+
+const searchInputSelector = `#search-input`
+
+// suggestWaitTime 输入关键词后等待联想结果刷新的时间
+const suggestWaitTime = 1500 * time.Millisecond
+
+// heatTextRegex 匹配热搜条目中的热度文本，如 "92.3万"、"1.2w热度"
+var heatTextRegex = regexp.MustCompile(`^[\d.,]+\s*[万亿wWkK千]?\+?\s*(热度)?$`)
+
+// SearchSuggestAction 读取搜索框的联想词和热搜榜
+type SearchSuggestAction struct {
+	page *rod.Page
+}
+
+func NewSearchSuggestAction(page *rod.Page) *SearchSuggestAction {
+	pp := page.Timeout(60 * time.Second)
+	return &SearchSuggestAction{page: pp}
+}
+
+// Suggest 在搜索框中输入前缀，返回下拉框中的联想词
+func (s *SearchSuggestAction) Suggest(ctx context.Context, prefix string) ([]string, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, fmt.Errorf("搜索前缀不能为空")
+	}
+
+	page := s.page.Context(ctx)
+	input, err := s.focusSearchInput(page)
+	if err != nil {
+		return nil, err
+	}
+
+	input.MustInput(prefix)
+	time.Sleep(suggestWaitTime)
+
+	result := page.MustEval(`() => {
+		const items = [];
+		document.querySelectorAll('.sug-container .sug-item, .sug-box .sug-item, .search-suggestion .item').forEach(el => {
+			const text = (el.innerText || "").trim();
+			if (text) {
+				items.push(text);
+			}
+		});
+		return JSON.stringify(items);
+	}`).String()
+
+	var raw []string
+	if err := json.Unmarshal([]byte(result), &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal suggestions: %w", err)
+	}
+
+	suggestions := normalizeSuggestions(raw)
+	logrus.Infof("搜索联想 %q 获取到 %d 条", prefix, len(suggestions))
+	return suggestions, nil
+}
+
+// TrendingKeywords 聚焦空的搜索框，读取下拉框中的热搜榜
+func (s *SearchSuggestAction) TrendingKeywords(ctx context.Context) ([]TrendingKeyword, error) {
+	page := s.page.Context(ctx)
+	if _, err := s.focusSearchInput(page); err != nil {
+		return nil, err
+	}
+	time.Sleep(suggestWaitTime)
+
+	result := page.MustEval(`() => {
+		const text = (root, selectors) => {
+			for (const sel of selectors) {
+				const el = root.querySelector(sel);
+				if (el && el.innerText && el.innerText.trim()) {
+					return el.innerText.trim();
+				}
+			}
+			return "";
+		};
+
+		const items = [];
+		document.querySelectorAll('.hotspot-list .hotspot-item, .hot-list .hot-item, .query-trending .item').forEach(el => {
+			items.push({
+				text: text(el, ['.hotspot-title', '.title', '.text', '.query']) || (el.innerText || "").trim(),
+				heat: text(el, ['.hotspot-score', '.score', '.hot-value', '.count']),
+				label: text(el, ['.hotspot-tag', '.tag', '.label', '.icon-text']),
+			});
+		});
+		return JSON.stringify(items);
+	}`).String()
+
+	var raw []trendingItemRaw
+	if err := json.Unmarshal([]byte(result), &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trending keywords: %w", err)
+	}
+
+	keywords := parseTrendingItems(raw)
+	if len(keywords) == 0 {
+		return nil, fmt.Errorf("未获取到热搜榜")
+	}
+	return keywords, nil
+}
+
+// focusSearchInput 打开首页并聚焦、清空搜索框
+func (s *SearchSuggestAction) focusSearchInput(page *rod.Page) (*rod.Element, error) {
+	page.MustNavigate("https://www.xiaohongshu.com/explore")
+	page.MustWaitDOMStable()
+
+	input, err := page.Element(searchInputSelector)
+	if err != nil {
+		return nil, fmt.Errorf("未找到搜索框: %w", err)
+	}
+
+	input.MustClick()
+	input.MustSelectAllText().MustInput("")
+	return input, nil
+}
+
+// normalizeSuggestions 去除联想词首尾空白、多行文本中的附加信息以及重复项
+func normalizeSuggestions(raw []string) []string {
+	suggestions := make([]string, 0, len(raw))
+	seen := make(map[string]bool)
+
+	for _, item := range raw {
+		text := strings.TrimSpace(strings.SplitN(item, "\n", 2)[0])
+		if text == "" || seen[text] {
+			continue
+		}
+		seen[text] = true
+		suggestions = append(suggestions, text)
+	}
+	return suggestions
+}
+
+// parseTrendingItems 整理热搜条目，去掉关键词中混入的序号、热度和标签
+func parseTrendingItems(raw []trendingItemRaw) []TrendingKeyword {
+	var keywords []TrendingKeyword
+	seen := make(map[string]bool)
+
+	for _, item := range raw {
+		heat := strings.TrimSpace(item.Heat)
+		label := strings.TrimSpace(item.Label)
+
+		var keyword string
+		for _, line := range strings.Split(item.Text, "\n") {
+			line = strings.TrimSpace(line)
+			switch {
+			case line == "" || line == label || isRankText(line):
+				continue
+			case heatTextRegex.MatchString(line):
+				if heat == "" {
+					heat = line
+				}
+				continue
+			}
+			if keyword == "" {
+				keyword = line
+			}
+		}
+
+		if keyword == "" || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+
+		keywords = append(keywords, TrendingKeyword{
+			Rank:    len(keywords) + 1,
+			Keyword: keyword,
+			Heat:    heat,
+			HeatNum: xhsutil.ParseCount(strings.TrimSuffix(heat, "热度")),
+			Label:   label,
+		})
+	}
+	return keywords
+}
+
+func isRankText(s string) bool {
+	if len(s) > 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
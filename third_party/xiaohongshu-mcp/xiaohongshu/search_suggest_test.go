@@ -0,0 +1,29 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSuggestions(t *testing.T) {
+	got := normalizeSuggestions([]string{" 猫咪 ", "猫咪粮\n约2万篇笔记", "", "猫咪"})
+	assert.Equal(t, []string{"猫咪", "猫咪粮"}, got)
+}
+
+func TestParseTrendingItems(t *testing.T) {
+	raw := []trendingItemRaw{
+		{Text: "1\n秋天的第一杯奶茶\n92.3万\n热", Label: "热"},
+		{Text: "2\n城市漫步", Heat: "1.2w热度"},
+		{Text: "城市漫步"},
+		{Text: "  "},
+		{Text: "3\n宠物日常\n新", Label: "新"},
+	}
+
+	got := parseTrendingItems(raw)
+	assert.Equal(t, []TrendingKeyword{
+		{Rank: 1, Keyword: "秋天的第一杯奶茶", Heat: "92.3万", HeatNum: 923000, Label: "热"},
+		{Rank: 2, Keyword: "城市漫步", Heat: "1.2w热度", HeatNum: 12000},
+		{Rank: 3, Keyword: "宠物日常", Label: "新"},
+	}, got)
+}
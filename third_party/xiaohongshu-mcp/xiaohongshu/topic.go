@@ -0,0 +1,263 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+)
+
+// TopicInfo 话题页的基本信息
+type TopicInfo struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ViewCount    string `json:"viewCount"`
+	ViewCountNum int64  `json:"viewCountNum"`
+	NoteCount    string `json:"noteCount"`
+	NoteCountNum int64  `json:"noteCountNum"`
+}
+
+// TopicFeedsResponse 话题页的信息及笔记列表
+type TopicFeedsResponse struct {
+	Topic TopicInfo `json:"topic"`
+	Feeds []Feed    `json:"feeds"`
+}
+
+// topicPageData 话题页 JS 提取的原始数据
+type topicPageData struct {
+	Name  string         `json:"name"`
+	Stats string         `json:"stats"`
+	Notes []topicNoteRaw `json:"notes"`
+}
+
+type topicNoteRaw struct {
+	Href      string `json:"href"`
+	Title     string `json:"title"`
+	Cover     string `json:"cover"`
+	Nickname  string `json:"nickname"`
+	LikeCount string `json:"likeCount"`
+}
+
+const (
+	defaultTopicFeedLimit = 20
+	topicMaxScrolls       = 10
+)
+
+var (
+	topicViewCountRegex = regexp.MustCompile(`([\d.,]+\s*[万亿wWkK千]?\+?)\s*次?浏览`)
+	topicNoteCountRegex = regexp.MustCompile(`([\d.,]+\s*[万亿wWkK千]?\+?)\s*篇?笔记`)
+	noteLinkIDRegex     = regexp.MustCompile(`/(?:explore|discovery/item)/([0-9a-zA-Z]+)`)
+	descTopicRegex      = regexp.MustCompile(`#([^#\[\]\s]+)\[话题\]#`)
+)
+
+type TopicAction struct {
+	page *rod.Page
+}
+
+func NewTopicAction(page *rod.Page) *TopicAction {
+	pp := page.Timeout(60 * time.Second)
+	return &TopicAction{page: pp}
+}
+
+// GetTopicFeeds 打开话题页，提取话题统计和笔记列表
+// limit 为需要的笔记数量，不足时向下滚动加载，0 表示使用默认值
+func (t *TopicAction) GetTopicFeeds(ctx context.Context, topicID string, limit int) (*TopicFeedsResponse, error) {
+	if limit <= 0 {
+		limit = defaultTopicFeedLimit
+	}
+
+	page := t.page.Context(ctx)
+
+	topicURL := makeTopicURL(topicID)
+	logrus.Infof("打开话题页: %s", topicURL)
+	page.MustNavigate(topicURL)
+	page.MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	data, err := extractTopicPage(page)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < topicMaxScrolls && len(data.Notes) < limit; i++ {
+		page.MustEval(`() => window.scrollBy(0, window.innerHeight * 0.8)`)
+		sleepRandom(postScrollRange.min, postScrollRange.max)
+
+		more, err := extractTopicPage(page)
+		if err != nil {
+			return nil, err
+		}
+		if len(more.Notes) <= len(data.Notes) {
+			break
+		}
+		data = more
+	}
+
+	views, notes := parseTopicStats(data.Stats)
+	resp := &TopicFeedsResponse{
+		Topic: TopicInfo{
+			ID:           topicID,
+			Name:         strings.TrimPrefix(strings.TrimSpace(data.Name), "#"),
+			ViewCount:    views,
+			ViewCountNum: xhsutil.ParseCount(views),
+			NoteCount:    notes,
+			NoteCountNum: xhsutil.ParseCount(notes),
+		},
+	}
+
+	seen := make(map[string]bool)
+	for _, raw := range data.Notes {
+		feed, ok := topicNoteToFeed(raw)
+		if !ok || seen[feed.ID] {
+			continue
+		}
+		seen[feed.ID] = true
+		feed.Index = len(resp.Feeds)
+		resp.Feeds = append(resp.Feeds, feed)
+		if len(resp.Feeds) >= limit {
+			break
+		}
+	}
+
+	if len(resp.Feeds) == 0 {
+		return nil, errors.ErrNoFeeds
+	}
+
+	return resp, nil
+}
+
+func extractTopicPage(page *rod.Page) (*topicPageData, error) {
+	result := page.MustEval(`() => {
+		const text = (root, selectors) => {
+			for (const sel of selectors) {
+				const el = root.querySelector(sel);
+				if (el && el.innerText && el.innerText.trim()) {
+					return el.innerText.trim();
+				}
+			}
+			return "";
+		};
+
+		const notes = [];
+		document.querySelectorAll('a[href*="/explore/"], a[href*="/discovery/item/"]').forEach(a => {
+			const card = a.closest('section, .note-item, .note-card, .feeds-card') || a;
+			const img = card.querySelector('img');
+			notes.push({
+				href: a.getAttribute('href') || "",
+				title: text(card, ['.title', '.note-title', '.desc']),
+				cover: img ? (img.getAttribute('src') || "") : "",
+				nickname: text(card, ['.author .name', '.user-name', '.nickname', '.name']),
+				likeCount: text(card, ['.like-wrapper .count', '.like .count', '.count']),
+			});
+		});
+
+		// 通用的 h1/.title/.info 只在话题头部容器内查找，避免匹配到笔记卡片的标题
+		const header = document.querySelector('.topic-header, .topic-head, .topic-detail, .page-header');
+		const name = text(document, ['.topic-name', '.topic-title', '.page-title']) ||
+			(header ? text(header, ['h1', '.title']) : "");
+		const stats = text(document, ['.topic-info', '.topic-desc', '.page-desc']) ||
+			(header ? text(header, ['.sub-title', '.info']) : "");
+
+		return JSON.stringify({
+			name: name,
+			stats: stats,
+			notes: notes,
+		});
+	}`).String()
+
+	if result == "" {
+		return nil, fmt.Errorf("话题页数据提取失败")
+	}
+
+	var data topicPageData
+	if err := json.Unmarshal([]byte(result), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal topic page: %w", err)
+	}
+	return &data, nil
+}
+
+// parseTopicStats 从话题统计文本中提取浏览量和笔记数，如 "3.4亿次浏览 · 120万篇笔记"
+func parseTopicStats(text string) (views, notes string) {
+	if m := topicViewCountRegex.FindStringSubmatch(text); len(m) > 1 {
+		views = strings.TrimSpace(m[1])
+	}
+	if m := topicNoteCountRegex.FindStringSubmatch(text); len(m) > 1 {
+		notes = strings.TrimSpace(m[1])
+	}
+	return views, notes
+}
+
+// parseNoteLink 从笔记链接中解析笔记 ID 和 xsec_token
+func parseNoteLink(href string) (noteID, xsecToken string) {
+	m := noteLinkIDRegex.FindStringSubmatch(href)
+	if len(m) < 2 {
+		return "", ""
+	}
+	noteID = m[1]
+
+	if u, err := url.Parse(href); err == nil {
+		xsecToken = u.Query().Get("xsec_token")
+	}
+	return noteID, xsecToken
+}
+
+func topicNoteToFeed(raw topicNoteRaw) (Feed, bool) {
+	noteID, xsecToken := parseNoteLink(raw.Href)
+	if noteID == "" {
+		return Feed{}, false
+	}
+
+	feed := Feed{
+		ID:        noteID,
+		XsecToken: xsecToken,
+		ModelType: "note",
+		NoteCard: NoteCard{
+			DisplayTitle: raw.Title,
+			User:         User{Nickname: raw.Nickname},
+			InteractInfo: InteractInfo{
+				LikedCount:    raw.LikeCount,
+				LikedCountNum: xhsutil.ParseCount(raw.LikeCount),
+			},
+			Cover: Cover{URL: raw.Cover, URLDefault: raw.Cover},
+		},
+	}
+	return feed, true
+}
+
+// ParseTopics 合并笔记 tagList 中的话题和正文里 "#话题[话题]#" 形式的话题
+// 只出现在正文里的话题页面上没有 ID，返回时 ID 为空，不能用于 topic_feeds
+func ParseTopics(desc string, tags []NoteTag) []NoteTag {
+	var topics []NoteTag
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		if tag.Name == "" || (tag.Type != "" && tag.Type != "topic") || seen[tag.Name] {
+			continue
+		}
+		seen[tag.Name] = true
+		topics = append(topics, tag)
+	}
+
+	for _, m := range descTopicRegex.FindAllStringSubmatch(desc, -1) {
+		name := m[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		topics = append(topics, NoteTag{Name: name, Type: "topic"})
+	}
+
+	return topics
+}
+
+func makeTopicURL(topicID string) string {
+	return fmt.Sprintf("https://www.xiaohongshu.com/page/topics/%s?naviHidden=yes", url.PathEscape(topicID))
+}
@@ -0,0 +1,55 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTopicStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantViews string
+		wantNotes string
+	}{
+		{name: "浏览和笔记", input: "3.4亿次浏览 · 120万篇笔记", wantViews: "3.4亿", wantNotes: "120万"},
+		{name: "无次字", input: "1.2万浏览 568笔记", wantViews: "1.2万", wantNotes: "568"},
+		{name: "仅浏览", input: "9999次浏览", wantViews: "9999", wantNotes: ""},
+		{name: "空文本", input: "", wantViews: "", wantNotes: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views, notes := parseTopicStats(tt.input)
+			assert.Equal(t, tt.wantViews, views)
+			assert.Equal(t, tt.wantNotes, notes)
+		})
+	}
+}
+
+func TestParseNoteLink(t *testing.T) {
+	id, token := parseNoteLink("/explore/64f1a2b3c4d5e6f7a8b9c0d1?xsec_token=ABC%3D&xsec_source=pc_feed")
+	assert.Equal(t, "64f1a2b3c4d5e6f7a8b9c0d1", id)
+	assert.Equal(t, "ABC=", token)
+
+	id, token = parseNoteLink("https://www.xiaohongshu.com/discovery/item/abc123")
+	assert.Equal(t, "abc123", id)
+	assert.Equal(t, "", token)
+
+	id, _ = parseNoteLink("/user/profile/u1")
+	assert.Equal(t, "", id)
+}
+
+func TestParseTopics(t *testing.T) {
+	desc := "周末去露营 #露营[话题]# #户外装备[话题]# #露营[话题]#"
+	tags := []NoteTag{
+		{ID: "t1", Name: "露营", Type: "topic"},
+		{ID: "l1", Name: "杭州", Type: "location"},
+	}
+
+	assert.Equal(t, []NoteTag{
+		{ID: "t1", Name: "露营", Type: "topic"},
+		{Name: "户外装备", Type: "topic"},
+	}, ParseTopics(desc, tags))
+}
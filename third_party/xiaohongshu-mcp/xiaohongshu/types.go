@@ -45,15 +45,20 @@ type User struct {
 }
 
 // InteractInfo 表示互动信息
+// *Num 字段由对应的计数文本解析而来（如 "1.2万" -> 12000），便于排序和统计
 type InteractInfo struct {
-	Liked      bool   `json:"liked"`
-	LikedCount string `json:"likedCount"`
+	Liked         bool   `json:"liked"`
+	LikedCount    string `json:"likedCount"`
+	LikedCountNum int64  `json:"likedCountNum"`
 
-	SharedCount  string `json:"sharedCount"`
-	CommentCount string `json:"commentCount"`
+	SharedCount     string `json:"sharedCount"`
+	SharedCountNum  int64  `json:"sharedCountNum"`
+	CommentCount    string `json:"commentCount"`
+	CommentCountNum int64  `json:"commentCountNum"`
 
-	CollectedCount string `json:"collectedCount"`
-	Collected      bool   `json:"collected"`
+	CollectedCount    string `json:"collectedCount"`
+	CollectedCountNum int64  `json:"collectedCountNum"`
+	Collected         bool   `json:"collected"`
 }
 
 // Cover 表示封面信息
@@ -103,15 +108,93 @@ type FeedDetail struct {
 	User         User              `json:"user"`
 	InteractInfo InteractInfo      `json:"interactInfo"`
 	ImageList    []DetailImageInfo `json:"imageList"`
+	Video        *DetailVideo      `json:"video,omitempty"`
+	TagList      []NoteTag         `json:"tagList,omitempty"`
+	AtUserList   []AtUser          `json:"atUserList,omitempty"`
+
+	// 以下字段由原始数据整理而来，不直接来自页面状态
+	Media    *NoteMedia `json:"media,omitempty"`
+	Mentions []Mention  `json:"mentions,omitempty"`
+	Topics   []NoteTag  `json:"topics,omitempty"` // 只出现在正文里的话题 ID 为空
 }
 
 // DetailImageInfo 表示详情页的图片信息
 type DetailImageInfo struct {
-	Width      int    `json:"width"`
-	Height     int    `json:"height"`
-	URLDefault string `json:"urlDefault"`
-	URLPre     string `json:"urlPre"`
-	LivePhoto  bool   `json:"livePhoto,omitempty"`
+	Width      int          `json:"width"`
+	Height     int          `json:"height"`
+	URLDefault string       `json:"urlDefault"`
+	URLPre     string       `json:"urlPre"`
+	LivePhoto  bool         `json:"livePhoto,omitempty"`
+	Stream     *MediaStream `json:"stream,omitempty"` // 实况图片的视频流
+}
+
+// DetailVideo 表示详情页的视频信息
+type DetailVideo struct {
+	Capa  VideoCapability `json:"capa"`
+	Media struct {
+		Stream MediaStream `json:"stream"`
+	} `json:"media"`
+}
+
+// MediaStream 表示按编码分组的视频流
+type MediaStream struct {
+	H264 []StreamInfo `json:"h264"`
+	H265 []StreamInfo `json:"h265"`
+	AV1  []StreamInfo `json:"av1"`
+}
+
+// StreamInfo 表示单路视频流
+type StreamInfo struct {
+	MasterURL  string   `json:"masterUrl"`
+	BackupURLs []string `json:"backupUrls"`
+	Width      int      `json:"width"`
+	Height     int      `json:"height"`
+	Duration   int64    `json:"duration"` // 单位毫秒
+	Size       int64    `json:"size"`
+	Format     string   `json:"format"`
+}
+
+// NoteTag 表示笔记关联的话题标签
+type NoteTag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// AtUser 表示笔记正文中 @ 到的用户
+type AtUser struct {
+	UserID    string `json:"userId"`
+	Nickname  string `json:"nickname"`
+	XsecToken string `json:"xsecToken"`
+}
+
+// NoteMedia 笔记媒体汇总：图片、实况图片和视频流地址
+type NoteMedia struct {
+	Images []NoteImage `json:"images"`
+	Video  *NoteVideo  `json:"video,omitempty"`
+}
+
+// NoteImage 单张图片，实况图片额外带有视频流地址
+type NoteImage struct {
+	URL          string `json:"url"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	LivePhoto    bool   `json:"livePhoto,omitempty"`
+	LivePhotoURL string `json:"livePhotoUrl,omitempty"`
+}
+
+// NoteVideo 视频笔记的播放信息
+type NoteVideo struct {
+	StreamURLs []string `json:"streamUrls"`
+	Duration   int      `json:"duration"` // 单位秒
+	CoverURL   string   `json:"coverUrl"`
+}
+
+// Mention 正文中的 @ 提及，能匹配到 atUserList 时带上用户 ID
+type Mention struct {
+	Nickname  string `json:"nickname"`
+	UserID    string `json:"userId,omitempty"`
+	XsecToken string `json:"xsecToken,omitempty"`
 }
 
 // CommentList 表示评论列表
@@ -123,17 +206,28 @@ type CommentList struct {
 
 // Comment 表示单条评论
 type Comment struct {
-	ID              string    `json:"id"`
-	NoteID          string    `json:"noteId"`
-	Content         string    `json:"content"`
-	LikeCount       string    `json:"likeCount"`
-	CreateTime      int64     `json:"createTime"`
-	IPLocation      string    `json:"ipLocation"`
-	Liked           bool      `json:"liked"`
-	UserInfo        User      `json:"userInfo"`
-	SubCommentCount string    `json:"subCommentCount"`
-	SubComments     []Comment `json:"subComments"`
-	ShowTags        []string  `json:"showTags"`
+	ID                 string    `json:"id"`
+	NoteID             string    `json:"noteId"`
+	Content            string    `json:"content"`
+	LikeCount          string    `json:"likeCount"`
+	LikeCountNum       int64     `json:"likeCountNum"`
+	CreateTime         int64     `json:"createTime"`
+	IPLocation         string    `json:"ipLocation"`
+	Liked              bool      `json:"liked"`
+	UserInfo           User      `json:"userInfo"`
+	SubCommentCount    string    `json:"subCommentCount"`
+	SubCommentCountNum int64     `json:"subCommentCountNum"`
+	SubComments        []Comment `json:"subComments"`
+	SubCommentHasMore  bool      `json:"subCommentHasMore"`
+	// TargetComment 子回复所回复的评论，直接回复一级评论时为该一级评论
+	TargetComment *TargetComment `json:"targetComment,omitempty"`
+	ShowTags      []string       `json:"showTags"`
+}
+
+// TargetComment 被回复的评论
+type TargetComment struct {
+	ID       string `json:"id"`
+	UserInfo User   `json:"userInfo"`
 }
 
 // UserProfileResponse 用户详情页完整响应
@@ -164,7 +258,8 @@ type UserBasicInfo struct {
 
 // UserInteractions 用户的 关注 粉丝 收藏量
 type UserInteractions struct {
-	Type  string `json:"type"`  // follows fans interaction
-	Name  string `json:"name"`  // 关注 粉丝 获赞与收藏
-	Count string `json:"count"` // 数量
+	Type     string `json:"type"`     // follows fans interaction
+	Name     string `json:"name"`     // 关注 粉丝 获赞与收藏
+	Count    string `json:"count"`    // 数量
+	CountNum int64  `json:"countNum"` // 数量（解析后的整数）
 }